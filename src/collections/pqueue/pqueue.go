@@ -0,0 +1,95 @@
+package pqueue
+
+type (
+	// `LessFunction` reports whether `a` should be popped before `b`.
+	LessFunction[T any] func(a, b T) bool
+
+	// `PriorityQueue` is a binary min-heap ordered by a `LessFunction`.
+	PriorityQueue[T any] struct {
+		less  LessFunction[T]
+		items []T
+	}
+)
+
+// `New` creates an empty `PriorityQueue` ordered by `less` and pushes the
+// provided values onto it.
+func New[T any](less LessFunction[T], values ...T) *PriorityQueue[T] {
+	pq := &PriorityQueue[T]{less, []T{}}
+	for _, value := range values {
+		pq.Push(value)
+	}
+	return pq
+}
+
+// `Len` returns the number of queued values.
+func (pq *PriorityQueue[T]) Len() int {
+	return len(pq.items)
+}
+
+// `Push` adds a value to the queue.
+func (pq *PriorityQueue[T]) Push(value T) {
+	pq.items = append(pq.items, value)
+	pq.up(len(pq.items) - 1)
+}
+
+// `Peek` returns the value that would be popped next without removing it.
+// Returns false when the queue is empty.
+func (pq *PriorityQueue[T]) Peek() (T, bool) {
+	if len(pq.items) == 0 {
+		return *new(T), false
+	}
+	return pq.items[0], true
+}
+
+// `Pop` removes and returns the smallest value according to the queue's
+// `LessFunction`. Returns false when the queue is empty.
+func (pq *PriorityQueue[T]) Pop() (T, bool) {
+	if len(pq.items) == 0 {
+		return *new(T), false
+	}
+
+	last := len(pq.items) - 1
+	top := pq.items[0]
+	pq.items[0] = pq.items[last]
+	pq.items[last] = *new(T)
+	pq.items = pq.items[:last]
+
+	if last > 0 {
+		pq.down(0)
+	}
+
+	return top, true
+}
+
+func (pq *PriorityQueue[T]) up(idx int) {
+	for idx > 0 {
+		parent := (idx - 1) / 2
+		if !pq.less(pq.items[idx], pq.items[parent]) {
+			return
+		}
+		pq.items[idx], pq.items[parent] = pq.items[parent], pq.items[idx]
+		idx = parent
+	}
+}
+
+func (pq *PriorityQueue[T]) down(idx int) {
+	size := len(pq.items)
+	for {
+		smallest := idx
+		left := 2*idx + 1
+		right := left + 1
+
+		if left < size && pq.less(pq.items[left], pq.items[smallest]) {
+			smallest = left
+		}
+		if right < size && pq.less(pq.items[right], pq.items[smallest]) {
+			smallest = right
+		}
+		if smallest == idx {
+			return
+		}
+
+		pq.items[idx], pq.items[smallest] = pq.items[smallest], pq.items[idx]
+		idx = smallest
+	}
+}
@@ -0,0 +1,58 @@
+package pqueue
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPopOrder(t *testing.T) {
+	values := []int{5, 3, 9, 1, 7, 3, 0, 12, -4}
+	pq := New(func(a, b int) bool { return a < b }, values...)
+
+	if pq.Len() != len(values) {
+		t.Fatalf("pq.Len() = %v, want %v", pq.Len(), len(values))
+	}
+
+	want := slices.Clone(values)
+	slices.Sort(want)
+
+	actual := []int{}
+	for pq.Len() > 0 {
+		value, ok := pq.Pop()
+		if !ok {
+			t.Fatalf("pq.Pop() = %v, %v, want a value", value, ok)
+		}
+		actual = append(actual, value)
+	}
+
+	if !slices.Equal(actual, want) {
+		t.Fatalf("popped %v, want %v", actual, want)
+	}
+}
+
+func TestPopEmpty(t *testing.T) {
+	pq := New(func(a, b string) bool { return a < b })
+
+	value, ok := pq.Pop()
+	if ok || value != "" {
+		t.Fatalf("pq.Pop() = %q, %v, want %q, %v", value, ok, "", false)
+	}
+
+	value, ok = pq.Peek()
+	if ok || value != "" {
+		t.Fatalf("pq.Peek() = %q, %v, want %q, %v", value, ok, "", false)
+	}
+}
+
+func TestPeek(t *testing.T) {
+	pq := New(func(a, b int) bool { return a > b }, 3, 8, 1)
+
+	value, ok := pq.Peek()
+	if !ok || value != 8 {
+		t.Fatalf("pq.Peek() = %v, %v, want %v, %v", value, ok, 8, true)
+	}
+
+	if pq.Len() != 3 {
+		t.Fatalf("pq.Len() = %v after Peek, want %v", pq.Len(), 3)
+	}
+}
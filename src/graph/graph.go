@@ -0,0 +1,276 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/wthys/advent-of-code-2023/collections/pqueue"
+)
+
+type (
+	// `Graph` is an undirected graph with weighted edges. Nodes are kept in
+	// the order they were added, so all algorithms are deterministic.
+	Graph[T comparable] struct {
+		nodes []T
+		index map[T]int
+		edges map[T]map[T]int
+	}
+
+	Edge[T comparable] struct {
+		A, B   T
+		Weight int
+	}
+
+	// `Cut` splits a `Graph` into two partitions, `Left` and `Right`, by
+	// removing `Edges`, which have a combined `Weight`.
+	Cut[T comparable] struct {
+		Weight      int
+		Edges       []Edge[T]
+		Left, Right []T
+	}
+
+	candidate struct {
+		node   int
+		weight int
+	}
+)
+
+// `New` creates an empty `Graph`.
+func New[T comparable]() *Graph[T] {
+	return &Graph[T]{[]T{}, map[T]int{}, map[T]map[T]int{}}
+}
+
+// `FromNeejbers` creates a `Graph` containing `nodes` and an edge with weight 1
+// between every node and each of its `neejbers`. Neejbers that are not in
+// `nodes` are added as well.
+func FromNeejbers[T comparable](nodes []T, neejbers func(node T) []T) *Graph[T] {
+	g := New[T]()
+	for _, node := range nodes {
+		g.AddNode(node)
+	}
+	for _, node := range nodes {
+		for _, neejber := range neejbers(node) {
+			g.AddEdge(node, neejber, 1)
+		}
+	}
+	return g
+}
+
+// `AddNode` adds a node without any edges, if it is not yet known.
+func (g *Graph[T]) AddNode(node T) {
+	if _, ok := g.edges[node]; ok {
+		return
+	}
+	g.index[node] = len(g.nodes)
+	g.nodes = append(g.nodes, node)
+	g.edges[node] = map[T]int{}
+}
+
+// `AddEdge` connects `a` and `b` with the given weight, replacing any existing
+// edge between them. Unknown nodes are added. Self-loops are ignored.
+func (g *Graph[T]) AddEdge(a, b T, weight int) {
+	g.AddNode(a)
+	g.AddNode(b)
+	if a == b {
+		return
+	}
+	g.edges[a][b] = weight
+	g.edges[b][a] = weight
+}
+
+// `RemoveEdge` disconnects `a` and `b`, if they were connected.
+func (g *Graph[T]) RemoveEdge(a, b T) {
+	if edges, ok := g.edges[a]; ok {
+		delete(edges, b)
+	}
+	if edges, ok := g.edges[b]; ok {
+		delete(edges, a)
+	}
+}
+
+// `Weight` returns the weight of the edge between `a` and `b`, or false when
+// they are not connected.
+func (g *Graph[T]) Weight(a, b T) (int, bool) {
+	weight, ok := g.edges[a][b]
+	return weight, ok
+}
+
+// `Nodes` returns all nodes in the order they were added.
+func (g *Graph[T]) Nodes() []T {
+	return append([]T{}, g.nodes...)
+}
+
+// `Neejbers` returns the nodes connected to `node`, in the order they were
+// added to the `Graph`.
+func (g *Graph[T]) Neejbers(node T) []T {
+	edges, ok := g.edges[node]
+	if !ok {
+		return []T{}
+	}
+
+	neejbers := []T{}
+	for other := range edges {
+		neejbers = append(neejbers, other)
+	}
+	slices.SortFunc(neejbers, func(a, b T) int {
+		return g.index[a] - g.index[b]
+	})
+	return neejbers
+}
+
+// `Edges` returns every edge once, ordered by the nodes they connect.
+func (g *Graph[T]) Edges() []Edge[T] {
+	edges := []Edge[T]{}
+	for _, a := range g.nodes {
+		for _, b := range g.Neejbers(a) {
+			if g.index[a] < g.index[b] {
+				edges = append(edges, Edge[T]{a, b, g.edges[a][b]})
+			}
+		}
+	}
+	return edges
+}
+
+// `Len` returns the number of nodes.
+func (g *Graph[T]) Len() int {
+	return len(g.nodes)
+}
+
+// `Components` splits the `Graph` into its connected components.
+func (g *Graph[T]) Components() [][]T {
+	seen := map[T]bool{}
+	components := [][]T{}
+
+	for _, start := range g.nodes {
+		if seen[start] {
+			continue
+		}
+
+		seen[start] = true
+		component := []T{start}
+		for idx := 0; idx < len(component); idx++ {
+			for _, neejber := range g.Neejbers(component[idx]) {
+				if !seen[neejber] {
+					seen[neejber] = true
+					component = append(component, neejber)
+				}
+			}
+		}
+		components = append(components, component)
+	}
+
+	return components
+}
+
+// `MinCut` finds a global minimum cut using the Stoer-Wagner algorithm. A
+// disconnected `Graph` has a cut of weight 0 between its components. Returns
+// an error when the `Graph` has fewer than two nodes.
+func (g *Graph[T]) MinCut() (Cut[T], error) {
+	size := len(g.nodes)
+	if size < 2 {
+		return Cut[T]{}, fmt.Errorf("need at least 2 nodes to cut, got %v", size)
+	}
+
+	adjacent := make([]map[int]int, size)
+	groups := make([][]int, size)
+	active := make([]int, size)
+	for idx, node := range g.nodes {
+		adjacent[idx] = map[int]int{}
+		for other, weight := range g.edges[node] {
+			adjacent[idx][g.index[other]] = weight
+		}
+		groups[idx] = []int{idx}
+		active[idx] = idx
+	}
+
+	best := -1
+	bestGroup := []int{}
+
+	for len(active) > 1 {
+		s, t, weight := minCutPhase(adjacent, active)
+
+		if best < 0 || weight < best {
+			best = weight
+			bestGroup = append([]int{}, groups[t]...)
+		}
+
+		// merge t into s
+		for other, w := range adjacent[t] {
+			delete(adjacent[other], t)
+			if other == s {
+				continue
+			}
+			adjacent[s][other] += w
+			adjacent[other][s] += w
+		}
+		adjacent[t] = nil
+		groups[s] = append(groups[s], groups[t]...)
+		groups[t] = nil
+		for idx, node := range active {
+			if node == t {
+				active = append(active[:idx], active[idx+1:]...)
+				break
+			}
+		}
+	}
+
+	left := make([]bool, size)
+	for _, idx := range bestGroup {
+		left[idx] = true
+	}
+
+	cut := Cut[T]{best, []Edge[T]{}, []T{}, []T{}}
+	for idx, node := range g.nodes {
+		if left[idx] {
+			cut.Left = append(cut.Left, node)
+		} else {
+			cut.Right = append(cut.Right, node)
+		}
+	}
+	for _, edge := range g.Edges() {
+		if left[g.index[edge.A]] != left[g.index[edge.B]] {
+			cut.Edges = append(cut.Edges, edge)
+		}
+	}
+
+	return cut, nil
+}
+
+// `minCutPhase` grows a set from the first active node by repeatedly adding
+// the most tightly connected node. Returns the last two nodes added and the
+// weight of the cut separating the last one from the rest.
+func minCutPhase(adjacent []map[int]int, active []int) (int, int, int) {
+	weights := map[int]int{}
+	added := map[int]bool{}
+	queue := pqueue.New(func(a, b candidate) bool {
+		if a.weight == b.weight {
+			return a.node < b.node
+		}
+		return a.weight > b.weight
+	})
+	for _, node := range active {
+		weights[node] = 0
+		queue.Push(candidate{node, 0})
+	}
+
+	s, t := -1, -1
+	for len(added) < len(active) {
+		next, _ := queue.Pop()
+		if added[next.node] || next.weight != weights[next.node] {
+			continue
+		}
+
+		added[next.node] = true
+		s, t = t, next.node
+
+		for other, w := range adjacent[next.node] {
+			if added[other] {
+				continue
+			}
+			weights[other] += w
+			queue.Push(candidate{other, weights[other]})
+		}
+	}
+
+	return s, t, weights[t]
+}
@@ -0,0 +1,115 @@
+package graph
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+var snowverload = []string{
+	"jqt: rhn xhk nvd",
+	"rsh: frs pzl lsr",
+	"xhk: hfx",
+	"cmg: qnr nvd lhk bvb",
+	"rhn: xhk bvb hfx",
+	"bvb: xhk hfx",
+	"pzl: lsr hfx nvd",
+	"qnr: nvd",
+	"ntq: jqt hfx bvb xhk",
+	"nvd: lhk",
+	"lsr: lhk",
+	"rzs: qnr cmg lsr rsh",
+	"frs: qnr lhk lsr",
+}
+
+func wiring(lines []string) *Graph[string] {
+	g := New[string]()
+	for _, line := range lines {
+		from, to, _ := strings.Cut(line, ": ")
+		for _, other := range strings.Fields(to) {
+			g.AddEdge(from, other, 1)
+		}
+	}
+	return g
+}
+
+func TestMinCut(t *testing.T) {
+	g := wiring(snowverload)
+
+	cut, err := g.MinCut()
+	if err != nil {
+		t.Fatalf("MinCut() = %v, want a cut", err)
+	}
+
+	if cut.Weight != 3 || len(cut.Edges) != 3 {
+		t.Fatalf("MinCut() = %v (%v edges), want weight %v with %v edges", cut.Weight, len(cut.Edges), 3, 3)
+	}
+
+	if product := len(cut.Left) * len(cut.Right); product != 54 {
+		t.Fatalf("MinCut() partitions %v x %v = %v, want %v", len(cut.Left), len(cut.Right), product, 54)
+	}
+
+	want := [][]string{{"cmg", "bvb"}, {"jqt", "nvd"}, {"pzl", "hfx"}}
+	for _, edge := range cut.Edges {
+		if !slices.ContainsFunc(want, func(pair []string) bool {
+			return pair[0] == edge.A && pair[1] == edge.B
+		}) && !slices.ContainsFunc(want, func(pair []string) bool {
+			return pair[0] == edge.B && pair[1] == edge.A
+		}) {
+			t.Fatalf("MinCut() cuts %v-%v, want one of %v", edge.A, edge.B, want)
+		}
+	}
+}
+
+func TestMinCutTooSmall(t *testing.T) {
+	g := New[int]()
+	g.AddNode(1)
+
+	if _, err := g.MinCut(); err == nil {
+		t.Fatalf("MinCut() on a single node should fail")
+	}
+}
+
+func TestComponents(t *testing.T) {
+	g := wiring(snowverload)
+	g.RemoveEdge("hfx", "pzl")
+	g.RemoveEdge("bvb", "cmg")
+	g.RemoveEdge("nvd", "jqt")
+
+	components := g.Components()
+	if len(components) != 2 {
+		t.Fatalf("Components() = %v, want 2 components", components)
+	}
+
+	sizes := []int{len(components[0]), len(components[1])}
+	slices.Sort(sizes)
+	if !slices.Equal(sizes, []int{6, 9}) {
+		t.Fatalf("Components() sizes = %v, want %v", sizes, []int{6, 9})
+	}
+
+	cut, _ := g.MinCut()
+	if cut.Weight != 0 || len(cut.Edges) != 0 {
+		t.Fatalf("MinCut() on a disconnected graph = %v, want %v", cut.Weight, 0)
+	}
+}
+
+func TestFromNeejbers(t *testing.T) {
+	g := FromNeejbers([]int{1, 2, 3, 4}, func(node int) []int {
+		if node%2 == 0 {
+			return []int{node - 1}
+		}
+		return []int{}
+	})
+
+	if g.Len() != 4 || len(g.Edges()) != 2 || len(g.Components()) != 2 {
+		t.Fatalf("FromNeejbers(...) = %v nodes, %v edges, %v components, want 4, 2, 2", g.Len(), len(g.Edges()), len(g.Components()))
+	}
+
+	if weight, ok := g.Weight(2, 1); !ok || weight != 1 {
+		t.Fatalf("Weight(2, 1) = %v, %v, want %v, %v", weight, ok, 1, true)
+	}
+
+	if neejbers := g.Neejbers(3); !slices.Equal(neejbers, []int{4}) {
+		t.Fatalf("Neejbers(3) = %v, want %v", neejbers, []int{4})
+	}
+}
@@ -0,0 +1,143 @@
+package grid
+
+import (
+	"fmt"
+
+	"github.com/wthys/advent-of-code-2023/location"
+)
+
+type (
+	// `DenseGrid` stores its values in a slice covering a fixed area. It is a
+	// lot faster than `Grid` when most of that area is filled, but cannot
+	// store values outside of it.
+	DenseGrid[T any] struct {
+		defaultFunc DefaultFunction[T]
+		area        Bounds
+		data        []T
+		present     []bool
+		count       int
+	}
+)
+
+// `NewDense` creates a `DenseGrid` covering `area` using the `DefaultError`
+// `DefaultFunction` for unknown `Location`s. Equivalent to
+// `DenseWithDefaultFunc(area, DefaultError())`.
+func NewDense[T any](area Bounds) *DenseGrid[T] {
+	return DenseWithDefaultFunc(area, DefaultError[T]())
+}
+
+// `DenseWithDefault` creates a `DenseGrid` covering `area` using the
+// `DefaultValue` `DefaultFunction` for unknown `Location`s. Equivalent to
+// `DenseWithDefaultFunc(area, DefaultValue(value))`.
+func DenseWithDefault[T any](area Bounds, value T) *DenseGrid[T] {
+	return DenseWithDefaultFunc(area, DefaultValue(value))
+}
+
+// `DenseWithDefaultFunc` creates a `DenseGrid` covering `area` using the
+// provided `DefaultFunction` for unknown `Location`s.
+func DenseWithDefaultFunc[T any](area Bounds, defaultFunc DefaultFunction[T]) *DenseGrid[T] {
+	size := max(area.Width(), 0) * max(area.Height(), 0)
+	return &DenseGrid[T]{defaultFunc, area, make([]T, size), make([]bool, size), 0}
+}
+
+func (g *DenseGrid[T]) index(loc location.Location) (int, bool) {
+	if !g.area.Has(loc) {
+		return -1, false
+	}
+	return (loc.Y-g.area.Ymin)*g.area.Width() + loc.X - g.area.Xmin, true
+}
+
+// `Area` returns the fixed area the `DenseGrid` can store values in.
+func (g *DenseGrid[T]) Area() Bounds {
+	return g.area
+}
+
+// `Get` retrieves the value stored at `loc`. If there is no value stored, the
+// `DenseGrid`'s `DefaultFunction` is called. If no `DefaultFunction` was set,
+// `DefaultError[T]()` is used.
+func (g *DenseGrid[T]) Get(loc location.Location) (T, error) {
+	idx, ok := g.index(loc)
+	if ok && g.present[idx] {
+		return g.data[idx], nil
+	}
+	if g.defaultFunc == nil {
+		return DefaultError[T]()(loc)
+	}
+
+	return g.defaultFunc(loc)
+}
+
+// `Set` stores a value at `loc`. Panics when `loc` is outside the `Area`.
+func (g *DenseGrid[T]) Set(loc location.Location, value T) {
+	idx, ok := g.index(loc)
+	if !ok {
+		panic(fmt.Sprintf("%v is outside of dense grid area %v", loc, g.area))
+	}
+	if !g.present[idx] {
+		g.present[idx] = true
+		g.count += 1
+	}
+	g.data[idx] = value
+}
+
+// `Remove` removes the stored value at `loc`, if any.
+func (g *DenseGrid[T]) Remove(loc location.Location) {
+	idx, ok := g.index(loc)
+	if !ok || !g.present[idx] {
+		return
+	}
+	g.present[idx] = false
+	g.data[idx] = *new(T)
+	g.count -= 1
+}
+
+// `ForEach` applies a function to all stored values, row by row. Both the
+// `Location` and the value are provided to the given `ForEachFunction`.
+func (g *DenseGrid[T]) ForEach(forEach ForEachFunction[T]) {
+	width := g.area.Width()
+	for idx, present := range g.present {
+		if present {
+			loc := location.New(g.area.Xmin+idx%width, g.area.Ymin+idx/width)
+			forEach(loc, g.data[idx])
+		}
+	}
+}
+
+// `Bounds` finds the bounding box of the `Location`s of the stored values, just
+// like `Grid.Bounds`. Use `Area` for the fixed area of the `DenseGrid`.
+// Returns an error when there are no stored values.
+func (g *DenseGrid[T]) Bounds() (Bounds, error) {
+	if g.count == 0 {
+		return Bounds{}, fmt.Errorf("no values in grid")
+	}
+
+	if g.count == len(g.present) {
+		return g.area, nil
+	}
+
+	bounds := Bounds{}
+	found := false
+	g.ForEach(func(loc location.Location, _ T) {
+		if !found {
+			bounds = BoundsFromLocation(loc)
+			found = true
+			return
+		}
+		bounds = bounds.Accomodate(loc)
+	})
+
+	return bounds, nil
+}
+
+// `Len` returns the number of stored values.
+func (g *DenseGrid[T]) Len() int {
+	return g.count
+}
+
+func (g *DenseGrid[T]) Print() {
+	g.PrintFunc(defaultStringer[T])
+}
+
+func (g *DenseGrid[T]) PrintFunc(stringer func(T, error) string) {
+	printFunc[T](g, stringer)
+}
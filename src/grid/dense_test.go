@@ -0,0 +1,217 @@
+package grid
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/wthys/advent-of-code-2023/location"
+)
+
+func TestDenseGetSet(t *testing.T) {
+	g := NewDense[string](Bounds{-2, 2, -1, 3})
+	loc := location.New(-2, 3)
+	want := "hello, world!"
+
+	if _, err := g.Get(loc); err == nil {
+		t.Fatalf("Get(%v) on empty dense grid should fail", loc)
+	}
+
+	g.Set(loc, want)
+
+	val, err := g.Get(loc)
+	if val != want || err != nil {
+		t.Fatalf("Get(%v) = %q, %v, want %q, %v", loc, val, err != nil, want, false)
+	}
+
+	outside := location.New(3, 0)
+	if _, err := g.Get(outside); err == nil {
+		t.Fatalf("Get(%v) outside the area should fail", outside)
+	}
+}
+
+func TestDenseSetOutside(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Set outside the area should panic")
+		}
+	}()
+
+	g := DenseWithDefault(Bounds{0, 1, 0, 1}, 0)
+	g.Set(location.New(2, 0), 1)
+}
+
+func TestDenseLenBounds(t *testing.T) {
+	g := DenseWithDefault(Bounds{0, 9, 0, 9}, 0)
+
+	g.Set(location.New(1, 2), 3)
+	g.Set(location.New(3, 7), 4)
+	g.Set(location.New(2, 5), 8)
+	g.Set(location.New(2, 5), 9)
+
+	if g.Len() != 3 {
+		t.Fatalf("g.Len() = %v, want %v", g.Len(), 3)
+	}
+
+	want := Bounds{1, 3, 2, 7}
+	bounds, err := g.Bounds()
+	if bounds != want || err != nil {
+		t.Fatalf("g.Bounds() = %v, %v, want %v, %v", bounds, err != nil, want, false)
+	}
+
+	g.Remove(location.New(3, 7))
+	g.Remove(location.New(3, 7))
+	if g.Len() != 2 {
+		t.Fatalf("g.Len() = %v after Remove, want %v", g.Len(), 2)
+	}
+
+	val, err := g.Get(location.New(3, 7))
+	if val != 0 || err != nil {
+		t.Fatalf("Get after Remove = %v, %v, want the default %v, %v", val, err, 0, nil)
+	}
+}
+
+func TestDenseForEach(t *testing.T) {
+	sparse := New[int]()
+	dense := NewDense[int](Bounds{-3, 3, -3, 3})
+
+	for _, g := range []Interface[int]{sparse, dense} {
+		g.Set(location.New(-3, -3), 1)
+		g.Set(location.New(0, 1), 2)
+		g.Set(location.New(3, 2), 3)
+	}
+
+	total := 0
+	dense.ForEach(func(loc location.Location, value int) {
+		expected, err := sparse.Get(loc)
+		if err != nil || expected != value {
+			t.Fatalf("dense value at %v = %v, want %v", loc, value, expected)
+		}
+		total += value
+	})
+
+	if total != 6 {
+		t.Fatalf("ForEach visited a total of %v, want %v", total, 6)
+	}
+}
+
+func loadExample(b *testing.B, name string) []string {
+	data, err := os.ReadFile(path.Join("..", "..", "examples", name))
+	if err != nil {
+		b.Skipf("example %v not available: %v", name, err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func fill(g Interface[rune], lines []string) {
+	for y, line := range lines {
+		for x, char := range line {
+			g.Set(location.New(x, y), char)
+		}
+	}
+}
+
+func linesArea(lines []string) Bounds {
+	return Bounds{0, len(lines[0]) - 1, 0, len(lines) - 1}
+}
+
+// `spin` rolls all round rocks as far north as they go, like day 14 does.
+func spin(g Interface[rune], bounds Bounds) {
+	for x := bounds.Xmin; x <= bounds.Xmax; x++ {
+		free := bounds.Ymin
+		for y := bounds.Ymin; y <= bounds.Ymax; y++ {
+			loc := location.New(x, y)
+			rock, _ := g.Get(loc)
+			switch rock {
+			case '#':
+				free = y + 1
+			case 'O':
+				g.Set(loc, '.')
+				g.Set(location.New(x, free), 'O')
+				free += 1
+			}
+		}
+	}
+}
+
+// `energise` follows a beam through the mirrors, like day 16 does.
+func energise(g Interface[rune], bounds Bounds) int {
+	type beam struct{ pos, dir location.Location }
+
+	seen := map[beam]bool{}
+	energised := map[location.Location]bool{}
+	beams := []beam{{location.New(-1, 0), location.New(1, 0)}}
+	for len(beams) > 0 {
+		b := beams[len(beams)-1]
+		beams = beams[:len(beams)-1]
+
+		pos := b.pos.Add(b.dir)
+		if !bounds.Has(pos) || seen[beam{pos, b.dir}] {
+			continue
+		}
+		seen[beam{pos, b.dir}] = true
+		energised[pos] = true
+
+		mirror, _ := g.Get(pos)
+		switch {
+		case mirror == '/':
+			beams = append(beams, beam{pos, location.New(-b.dir.Y, -b.dir.X)})
+		case mirror == '\\':
+			beams = append(beams, beam{pos, location.New(b.dir.Y, b.dir.X)})
+		case mirror == '|' && b.dir.Y == 0, mirror == '-' && b.dir.X == 0:
+			beams = append(beams, beam{pos, location.New(b.dir.Y, b.dir.X)}, beam{pos, location.New(-b.dir.Y, -b.dir.X)})
+		default:
+			beams = append(beams, beam{pos, b.dir})
+		}
+	}
+	return len(energised)
+}
+
+func benchmarkSpin(b *testing.B, create func(area Bounds) Interface[rune]) {
+	lines := loadExample(b, "day14.txt")
+	area := linesArea(lines)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g := create(area)
+		fill(g, lines)
+		for n := 0; n < 100; n++ {
+			spin(g, area)
+		}
+	}
+}
+
+func benchmarkEnergise(b *testing.B, create func(area Bounds) Interface[rune]) {
+	lines := loadExample(b, "day16.txt")
+	area := linesArea(lines)
+	g := create(area)
+	fill(g, lines)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		energise(g, area)
+	}
+}
+
+func sparseRunes(_ Bounds) Interface[rune] {
+	return New[rune]()
+}
+
+func denseRunes(area Bounds) Interface[rune] {
+	return NewDense[rune](area)
+}
+
+func BenchmarkSpinGrid(b *testing.B) {
+	benchmarkSpin(b, sparseRunes)
+}
+
+func BenchmarkSpinDenseGrid(b *testing.B) {
+	benchmarkSpin(b, denseRunes)
+}
+
+func BenchmarkEnergiseGrid(b *testing.B) {
+	benchmarkEnergise(b, sparseRunes)
+}
+
+func BenchmarkEnergiseDenseGrid(b *testing.B) {
+	benchmarkEnergise(b, denseRunes)
+}
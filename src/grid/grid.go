@@ -18,6 +18,24 @@ type (
 
 	DefaultFunction[T any] func(loc location.Location) (T, error)
 	ForEachFunction[T any] func(loc location.Location, value T)
+
+	// `Interface` is implemented by both the map-backed `Grid` and the
+	// array-backed `DenseGrid`, so solutions can switch between them.
+	Interface[T any] interface {
+		Get(loc location.Location) (T, error)
+		Set(loc location.Location, value T)
+		Remove(loc location.Location)
+		ForEach(forEach ForEachFunction[T])
+		Bounds() (Bounds, error)
+		Len() int
+		Print()
+		PrintFunc(stringer func(T, error) string)
+	}
+)
+
+var (
+	_ Interface[int] = (*Grid[int])(nil)
+	_ Interface[int] = (*DenseGrid[int])(nil)
 )
 
 // `DefaultValue` creates a `DefaultFunction` that always returns the provided
@@ -136,15 +154,21 @@ func (g *Grid[T]) Len() int {
 }
 
 func (g *Grid[T]) Print() {
-	g.PrintFunc(func(val T, err error) string {
-		if err != nil {
-			return "."
-		}
-		return fmt.Sprint(val)
-	})
+	g.PrintFunc(defaultStringer[T])
 }
 
 func (g *Grid[T]) PrintFunc(stringer func(T, error) string) {
+	printFunc[T](g, stringer)
+}
+
+func defaultStringer[T any](val T, err error) string {
+	if err != nil {
+		return "."
+	}
+	return fmt.Sprint(val)
+}
+
+func printFunc[T any](g Interface[T], stringer func(T, error) string) {
 	bounds, err := g.Bounds()
 
 	if err != nil {
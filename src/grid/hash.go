@@ -0,0 +1,84 @@
+package grid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"io"
+
+	"github.com/wthys/advent-of-code-2023/location"
+)
+
+// `Hash` calculates a hash of the stored values and their `Location`s. Grids
+// with the same contents have the same hash, whatever order the values were
+// stored in, which makes it a good key for cycle detection.
+func (g *Grid[T]) Hash() uint64 {
+	return hashOf(g, func(loc location.Location) (T, bool) {
+		value, ok := g.data[loc]
+		return value, ok
+	})
+}
+
+// `Hash` calculates a hash of the stored values and their `Location`s. A
+// `DenseGrid` has the same hash as a `Grid` with the same contents.
+func (g *DenseGrid[T]) Hash() uint64 {
+	return hashOf(g, func(loc location.Location) (T, bool) {
+		idx, ok := g.index(loc)
+		if !ok || !g.present[idx] {
+			return *new(T), false
+		}
+		return g.data[idx], true
+	})
+}
+
+func hashOf[T any](g Interface[T], lookup func(loc location.Location) (T, bool)) uint64 {
+	hash := fnv.New64a()
+
+	bounds, err := g.Bounds()
+	if err != nil {
+		return hash.Sum64()
+	}
+
+	buf := make([]byte, 8)
+	writeInt := func(val int) {
+		binary.LittleEndian.PutUint64(buf, uint64(val))
+		hash.Write(buf)
+	}
+
+	bounds.ForEach(func(loc location.Location) {
+		value, ok := lookup(loc)
+		if !ok {
+			return
+		}
+		writeInt(loc.X)
+		writeInt(loc.Y)
+		writeValue(hash, value)
+	})
+
+	return hash.Sum64()
+}
+
+func writeValue(w io.Writer, value any) {
+	buf := make([]byte, 8)
+	switch v := value.(type) {
+	case int:
+		binary.LittleEndian.PutUint64(buf, uint64(v))
+		w.Write(buf)
+	case int32:
+		binary.LittleEndian.PutUint32(buf, uint32(v))
+		w.Write(buf[:4])
+	case uint8:
+		w.Write([]byte{v})
+	case bool:
+		if v {
+			w.Write([]byte{1})
+		} else {
+			w.Write([]byte{0})
+		}
+	case string:
+		io.WriteString(w, v)
+		w.Write([]byte{0})
+	default:
+		fmt.Fprintf(w, "%T:%v\x00", v, v)
+	}
+}
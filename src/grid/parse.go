@@ -0,0 +1,143 @@
+package grid
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wthys/advent-of-code-2023/location"
+)
+
+type (
+	// `RuneMapper` converts a character of the puzzle input at `loc` into a
+	// value to store in the `Grid`.
+	RuneMapper[T any] func(loc location.Location, char rune) (T, error)
+
+	// `Parser` turns puzzle input lines into a `Grid`. Every character is
+	// converted with `Mapper`, except those in `Skip`, which are not stored.
+	// The locations of characters in `Markers` are recorded, whether they are
+	// skipped or not. `Default` is the `DefaultFunction` of the resulting
+	// `Grid`, `DefaultError` when nil.
+	Parser[T any] struct {
+		Mapper  RuneMapper[T]
+		Skip    string
+		Markers string
+		Default DefaultFunction[T]
+	}
+
+	// `Parsed` is the result of parsing a single grid. `Bounds` covers the
+	// whole input area, including skipped characters.
+	Parsed[T any] struct {
+		Grid    *Grid[T]
+		Bounds  Bounds
+		Markers map[rune][]location.Location
+	}
+)
+
+// `Runes` is a `RuneMapper` that stores the characters as they are.
+func Runes(_ location.Location, char rune) (rune, error) {
+	return char, nil
+}
+
+// `RuneMap` creates a `RuneMapper` that looks up each character in `values`.
+// Unknown characters result in an error.
+func RuneMap[T any](values map[rune]T) RuneMapper[T] {
+	return func(loc location.Location, char rune) (T, error) {
+		value, ok := values[char]
+		if !ok {
+			return *new(T), fmt.Errorf("unknown character %q", char)
+		}
+		return value, nil
+	}
+}
+
+// `Marker` returns the location of the first occurrence of `marker`, if it was
+// recorded.
+func (p Parsed[T]) Marker(marker rune) (location.Location, bool) {
+	locs := p.Markers[marker]
+	if len(locs) == 0 {
+		return location.Location{}, false
+	}
+	return locs[0], true
+}
+
+// `Parse` parses `lines` as a single grid, with the top left character at
+// (0,0). Blank lines before or after the grid are ignored. Returns an error
+// when there is no grid, more than one, or the `Mapper` fails.
+func (p Parser[T]) Parse(lines []string) (Parsed[T], error) {
+	grids, err := p.ParseAll(lines)
+	if err != nil {
+		return Parsed[T]{}, err
+	}
+
+	if len(grids) != 1 {
+		return Parsed[T]{}, fmt.Errorf("expected 1 grid, found %v", len(grids))
+	}
+
+	return grids[0], nil
+}
+
+// `ParseAll` parses `lines` as a series of grids separated by blank lines.
+// Each grid has its top left character at (0,0). Returns an error when there
+// are no grids or the `Mapper` fails.
+func (p Parser[T]) ParseAll(lines []string) ([]Parsed[T], error) {
+	grids := []Parsed[T]{}
+
+	start := -1
+	for lineNr := 0; lineNr <= len(lines); lineNr++ {
+		blank := lineNr == len(lines) || len(strings.TrimSpace(lines[lineNr])) == 0
+		if !blank {
+			if start < 0 {
+				start = lineNr
+			}
+			continue
+		}
+		if start < 0 {
+			continue
+		}
+
+		parsed, err := p.parse(lines[start:lineNr], start)
+		if err != nil {
+			return nil, err
+		}
+		grids = append(grids, parsed)
+		start = -1
+	}
+
+	if len(grids) == 0 {
+		return nil, fmt.Errorf("no grid found")
+	}
+
+	return grids, nil
+}
+
+func (p Parser[T]) parse(lines []string, offset int) (Parsed[T], error) {
+	defaultFunc := p.Default
+	if defaultFunc == nil {
+		defaultFunc = DefaultError[T]()
+	}
+
+	parsed := Parsed[T]{WithDefaultFunc(defaultFunc), Bounds{}, map[rune][]location.Location{}}
+	parsed.Bounds = BoundsFromLocation(location.New(0, 0))
+
+	for y, line := range lines {
+		for x, char := range []rune(line) {
+			loc := location.New(x, y)
+			parsed.Bounds = parsed.Bounds.Accomodate(loc)
+
+			if strings.ContainsRune(p.Markers, char) {
+				parsed.Markers[char] = append(parsed.Markers[char], loc)
+			}
+			if strings.ContainsRune(p.Skip, char) {
+				continue
+			}
+
+			value, err := p.Mapper(loc, char)
+			if err != nil {
+				return Parsed[T]{}, fmt.Errorf("line #%v, column #%v: %w", offset+y+1, x+1, err)
+			}
+			parsed.Grid.Set(loc, value)
+		}
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,89 @@
+package grid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wthys/advent-of-code-2023/location"
+)
+
+type (
+	TestCase[I any, E any] struct {
+		input    I
+		expected E
+	}
+)
+
+func TestParse(t *testing.T) {
+	lines := strings.Split("..F7.\n.FJ|.\nSJ.L7\n|F--J\nLJ...\n", "\n")
+
+	parser := Parser[rune]{Mapper: Runes, Skip: ".", Markers: "S"}
+	parsed, err := parser.Parse(lines)
+	if err != nil {
+		t.Fatalf("Parse(...) = %v, want a grid", err)
+	}
+
+	want := Bounds{0, 4, 0, 4}
+	if parsed.Bounds != want {
+		t.Fatalf("Parse(...).Bounds = %v, want %v", parsed.Bounds, want)
+	}
+
+	if parsed.Grid.Len() != 16 {
+		t.Fatalf("Parse(...).Grid.Len() = %v, want %v", parsed.Grid.Len(), 16)
+	}
+
+	start, ok := parsed.Marker('S')
+	if !ok || start != location.New(0, 2) {
+		t.Fatalf("Parse(...).Marker('S') = %v, %v, want %v, %v", start, ok, location.New(0, 2), true)
+	}
+
+	if _, err := parsed.Grid.Get(location.New(0, 0)); err == nil {
+		t.Fatalf("skipped rune at (0,0) should not be stored")
+	}
+
+	if val, _ := parsed.Grid.Get(location.New(3, 1)); val != '|' {
+		t.Fatalf("Get((3,1)) = %q, want %q", val, '|')
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	lines := strings.Split("#.##..##.\n..#.##.#.\n\n#...##..#\n#....#..#\n..##..###\n", "\n")
+
+	parser := Parser[bool]{Mapper: RuneMap(map[rune]bool{'#': true, '.': false}), Default: DefaultValue(false)}
+	grids, err := parser.ParseAll(lines)
+	if err != nil {
+		t.Fatalf("ParseAll(...) = %v, want grids", err)
+	}
+
+	if len(grids) != 2 {
+		t.Fatalf("ParseAll(...) found %v grids, want %v", len(grids), 2)
+	}
+
+	testcases := []TestCase[Bounds, int]{
+		{Bounds{0, 8, 0, 1}, 18},
+		{Bounds{0, 8, 0, 2}, 27},
+	}
+
+	for idx, tc := range testcases {
+		if grids[idx].Bounds != tc.input || grids[idx].Grid.Len() != tc.expected {
+			t.Fatalf("grid #%v = %v with %v values, want %v with %v", idx, grids[idx].Bounds, grids[idx].Grid.Len(), tc.input, tc.expected)
+		}
+	}
+
+	if _, err := parser.Parse(lines); err == nil {
+		t.Fatalf("Parse(...) should fail on multiple grids")
+	}
+}
+
+func TestParseError(t *testing.T) {
+	parser := Parser[bool]{Mapper: RuneMap(map[rune]bool{'#': true, '.': false})}
+
+	_, err := parser.Parse([]string{"", "#.#", "#x#"})
+	if err == nil || !strings.Contains(err.Error(), "line #3, column #2") {
+		t.Fatalf("Parse(...) = %v, want an error on line #3, column #2", err)
+	}
+
+	if _, err := parser.Parse([]string{"", ""}); err == nil {
+		t.Fatalf("Parse of blank lines should fail")
+	}
+}
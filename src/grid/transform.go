@@ -0,0 +1,94 @@
+package grid
+
+import (
+	"github.com/wthys/advent-of-code-2023/location"
+)
+
+// `transform` creates a new `Grid` with the same `DefaultFunction`, moving
+// every stored value to the `Location` given by `mapper`. The mapper receives
+// the `Location` relative to the top left of the `Bounds`, together with the
+// width and height of the `Bounds`, and should return a relative `Location` as
+// well.
+func (g *Grid[T]) transform(mapper func(rel location.Location, width, height int) location.Location) *Grid[T] {
+	result := WithDefaultFunc(g.defaultFunc)
+
+	bounds, err := g.Bounds()
+	if err != nil {
+		return result
+	}
+
+	topLeft := location.New(bounds.Xmin, bounds.Ymin)
+	g.ForEach(func(loc location.Location, value T) {
+		rel := mapper(loc.Subtract(topLeft), bounds.Width(), bounds.Height())
+		result.Set(rel.Add(topLeft), value)
+	})
+
+	return result
+}
+
+// `Rotate90` returns a new `Grid` turned a quarter clockwise (with y pointing
+// down) within its `Bounds`. The top left corner of the `Bounds` stays put,
+// while width and height are swapped.
+func (g *Grid[T]) Rotate90() *Grid[T] {
+	return g.transform(func(rel location.Location, _, height int) location.Location {
+		return location.New(height-1-rel.Y, rel.X)
+	})
+}
+
+// `Rotate180` returns a new `Grid` turned upside down within its `Bounds`.
+func (g *Grid[T]) Rotate180() *Grid[T] {
+	return g.transform(func(rel location.Location, width, height int) location.Location {
+		return location.New(width-1-rel.X, height-1-rel.Y)
+	})
+}
+
+// `Rotate270` returns a new `Grid` turned a quarter counterclockwise (with y
+// pointing down) within its `Bounds`. The top left corner of the `Bounds` stays
+// put, while width and height are swapped.
+func (g *Grid[T]) Rotate270() *Grid[T] {
+	return g.transform(func(rel location.Location, width, _ int) location.Location {
+		return location.New(rel.Y, width-1-rel.X)
+	})
+}
+
+// `FlipH` returns a new `Grid` mirrored left to right within its `Bounds`.
+func (g *Grid[T]) FlipH() *Grid[T] {
+	return g.transform(func(rel location.Location, width, _ int) location.Location {
+		return location.New(width-1-rel.X, rel.Y)
+	})
+}
+
+// `FlipV` returns a new `Grid` mirrored top to bottom within its `Bounds`.
+func (g *Grid[T]) FlipV() *Grid[T] {
+	return g.transform(func(rel location.Location, _, height int) location.Location {
+		return location.New(rel.X, height-1-rel.Y)
+	})
+}
+
+// `Transpose` returns a new `Grid` mirrored along the diagonal starting in the
+// top left corner of its `Bounds`.
+func (g *Grid[T]) Transpose() *Grid[T] {
+	return g.transform(func(rel location.Location, _, _ int) location.Location {
+		return location.New(rel.Y, rel.X)
+	})
+}
+
+// `Translate` returns a new `Grid` with every value moved by `offset`.
+func (g *Grid[T]) Translate(offset location.Location) *Grid[T] {
+	result := WithDefaultFunc(g.defaultFunc)
+	g.ForEach(func(loc location.Location, value T) {
+		result.Set(loc.Add(offset), value)
+	})
+	return result
+}
+
+// `Crop` returns a new `Grid` with only the values within `bounds`.
+func (g *Grid[T]) Crop(bounds Bounds) *Grid[T] {
+	result := WithDefaultFunc(g.defaultFunc)
+	g.ForEach(func(loc location.Location, value T) {
+		if bounds.Has(loc) {
+			result.Set(loc, value)
+		}
+	})
+	return result
+}
@@ -0,0 +1,112 @@
+package grid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wthys/advent-of-code-2023/location"
+)
+
+func runeGrid(t *testing.T, input string) *Grid[rune] {
+	parser := Parser[rune]{Mapper: Runes, Default: DefaultValue('?')}
+	parsed, err := parser.Parse(strings.Split(input, "\n"))
+	if err != nil {
+		t.Fatalf("could not parse %q: %v", input, err)
+	}
+	return parsed.Grid
+}
+
+func gridString(g *Grid[rune]) string {
+	bounds, err := g.Bounds()
+	if err != nil {
+		return ""
+	}
+
+	lines := []string{}
+	for y := bounds.Ymin; y <= bounds.Ymax; y++ {
+		line := []rune{}
+		for x := bounds.Xmin; x <= bounds.Xmax; x++ {
+			char, _ := g.Get(location.New(x, y))
+			line = append(line, char)
+		}
+		lines = append(lines, string(line))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestTransforms(t *testing.T) {
+	input := "abc\ndef"
+
+	testcases := []TestCase[func(*Grid[rune]) *Grid[rune], string]{
+		{(*Grid[rune]).Rotate90, "da\neb\nfc"},
+		{(*Grid[rune]).Rotate180, "fed\ncba"},
+		{(*Grid[rune]).Rotate270, "cf\nbe\nad"},
+		{(*Grid[rune]).FlipH, "cba\nfed"},
+		{(*Grid[rune]).FlipV, "def\nabc"},
+		{(*Grid[rune]).Transpose, "ad\nbe\ncf"},
+	}
+
+	for idx, tc := range testcases {
+		actual := gridString(tc.input(runeGrid(t, input)))
+		if actual != tc.expected {
+			t.Fatalf("transform #%v of %q = %q, want %q", idx, input, actual, tc.expected)
+		}
+	}
+}
+
+func TestRotateKeepsCorner(t *testing.T) {
+	g := runeGrid(t, "abc\ndef").Translate(location.New(5, -3))
+
+	rotated := g.Rotate90()
+	bounds, _ := rotated.Bounds()
+	want := Bounds{5, 6, -3, -1}
+	if bounds != want {
+		t.Fatalf("Rotate90().Bounds() = %v, want %v", bounds, want)
+	}
+
+	if full := rotated.Rotate90().Rotate90().Rotate90(); gridString(full) != gridString(g) {
+		t.Fatalf("four quarter turns = %q, want %q", gridString(full), gridString(g))
+	}
+}
+
+func TestTranslateCrop(t *testing.T) {
+	g := runeGrid(t, "abc\ndef")
+
+	moved := g.Translate(location.New(2, 1))
+	if val, _ := moved.Get(location.New(2, 1)); val != 'a' {
+		t.Fatalf("Translate((2,1)).Get((2,1)) = %q, want %q", val, 'a')
+	}
+
+	cropped := g.Crop(Bounds{1, 2, 0, 0})
+	if actual := gridString(cropped); actual != "bc" {
+		t.Fatalf("Crop(...) = %q, want %q", actual, "bc")
+	}
+
+	if val, err := cropped.Get(location.New(0, 0)); val != '?' || err != nil {
+		t.Fatalf("Crop(...).Get((0,0)) = %q, %v, want the default %q", val, err, '?')
+	}
+}
+
+func TestHash(t *testing.T) {
+	a := runeGrid(t, "abc\ndef")
+	b := New[rune]()
+	dense := NewDense[rune](Bounds{0, 2, 0, 1})
+	for _, loc := range []location.Location{location.New(2, 1), location.New(0, 0), location.New(1, 1), location.New(2, 0), location.New(1, 0), location.New(0, 1)} {
+		val, _ := a.Get(loc)
+		b.Set(loc, val)
+		dense.Set(loc, val)
+	}
+
+	if a.Hash() != b.Hash() || a.Hash() != dense.Hash() {
+		t.Fatalf("equal grids have different hashes: %v, %v, %v", a.Hash(), b.Hash(), dense.Hash())
+	}
+
+	if a.Hash() == a.FlipH().Hash() {
+		t.Fatalf("flipped grid has the same hash %v", a.Hash())
+	}
+
+	b.Remove(location.New(1, 1))
+	if a.Hash() == b.Hash() {
+		t.Fatalf("grid with a removed value has the same hash %v", a.Hash())
+	}
+}
@@ -6,15 +6,28 @@ import (
     "os"
     "fmt"
     "bufio"
+    "bytes"
+    "io"
+    "path/filepath"
+    "strconv"
+    "time"
 
     log "github.com/obalunenko/logger"
     "github.com/urfave/cli/v2"
 
+    "github.com/wthys/advent-of-code-2023/scaffold"
     "github.com/wthys/advent-of-code-2023/solver"
     _ "github.com/wthys/advent-of-code-2023/solutions"
 )
 
 
+func onStart(ctx context.Context) cli.BeforeFunc {
+    return func(c *cli.Context) error {
+        solver.UserAgent = c.String("user-agent")
+        return nil
+    }
+}
+
 func onExit(ctx context.Context) cli.AfterFunc {
     return func(c *cli.Context) error {
         return nil
@@ -35,6 +48,99 @@ func notFound(ctx context.Context) cli.CommandNotFoundFunc {
 }
 
 
+// parseDay returns a day as it is registered and cached, so "05" and "5" are
+// the same day.
+func parseDay(day string) (string, error) {
+    if day == "" {
+        return "", errors.New("no puzzle provided")
+    }
+    n, err := strconv.Atoi(day)
+    if err != nil || n < 1 || n > 25 {
+        return "", fmt.Errorf("invalid day %q, expected 1 to 25", day)
+    }
+    return strconv.Itoa(n), nil
+}
+
+// parseDays returns all days in the arguments like parseDay.
+func parseDays(c *cli.Context) ([]string, error) {
+    days := []string{}
+    for _, arg := range c.Args().Slice() {
+        day, err := parseDay(arg)
+        if err != nil {
+            return nil, err
+        }
+        days = append(days, day)
+    }
+    return days, nil
+}
+
+func userAgentFlag() *cli.StringFlag {
+    return &cli.StringFlag{
+        Name: "user-agent",
+        Usage: "User-Agent sent to AoC, which should tell them how to contact you",
+        EnvVars: []string{"AOC_USER_AGENT"},
+        Value: solver.UserAgent,
+        Required: false,
+        HasBeenSet: false,
+    }
+}
+
+func yearFlag() *cli.StringFlag {
+    return &cli.StringFlag{
+        Name: "year",
+        Aliases: []string{"y"},
+        Usage: "Year of the event the puzzles belong to",
+        EnvVars: []string{"AOC_YEAR"},
+        Value: solver.DefaultYear,
+        Required: false,
+        HasBeenSet: false,
+    }
+}
+
+func sessionFlag() *cli.StringFlag {
+    return &cli.StringFlag{
+        Name: "session",
+        Aliases: []string{"s"},
+        Usage: "AOC Auth session token for getting inputs directly",
+        EnvVars: []string{"AOC_SESSION"},
+        Required: false,
+        HasBeenSet: false,
+    }
+}
+
+func cacheFlag() *cli.StringFlag {
+    return &cli.StringFlag{
+        Name: "cache",
+        Usage: "Directory where fetched inputs are cached",
+        EnvVars: []string{"AOC_CACHE"},
+        Value: solver.DefaultCacheDir(),
+        Required: false,
+        HasBeenSet: false,
+    }
+}
+
+func cachedFlag() *cli.BoolFlag {
+    return &cli.BoolFlag{
+        Name: "cached",
+        Aliases: []string{"c"},
+        Usage: "Read inputs from the cache instead, fetching them when missing",
+        Required: false,
+        HasBeenSet: false,
+    }
+}
+
+
+func parallelFlag() *cli.BoolFlag {
+    return &cli.BoolFlag{
+        Name: "parallel",
+        Aliases: []string{"p"},
+        Usage: "Solve both parts at the same time",
+        Required: false,
+        HasBeenSet: false,
+    }
+}
+
+
 func cmdRunFlags() []cli.Flag {
     var flags []cli.Flag
 
@@ -46,7 +152,24 @@ func cmdRunFlags() []cli.Flag {
         HasBeenSet: false,
     }
 
-    flags = append(flags, &elapsed)
+    timeout := cli.DurationFlag{
+        Name: "timeout",
+        Aliases: []string{"t"},
+        Usage: "Abort solving after this long, e.g. 30s (0 means no limit)",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    format := cli.StringFlag{
+        Name: "format",
+        Aliases: []string{"f"},
+        Usage: fmt.Sprintf("Output format, one of %v", solver.Formats()),
+        Value: string(solver.FormatText),
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    flags = append(flags, yearFlag(), &elapsed, &timeout, &format, parallelFlag(), cachedFlag(), cacheFlag(), sessionFlag())
 
     return flags
 }
@@ -54,40 +177,442 @@ func cmdRunFlags() []cli.Flag {
 func cmdRun(ctx context.Context) cli.ActionFunc {
     return func (c *cli.Context) error {
         if c.Bool("elapsed") || c.Bool("e") {
-            ctx = context.WithValue(ctx, "elapsed", true)
+            ctx = solver.WithElapsed(ctx)
+        }
+        if c.Bool("parallel") {
+            ctx = solver.WithParallel(ctx)
         }
 
-        s, err := solver.GetSolver(c.Args().First())
+        format, err := solver.ParseFormat(c.String("format"))
         if err != nil {
             return err
         }
 
-        res, err := solver.Solve(s, bufio.NewReader(os.Stdin), ctx)
+        day, err := parseDay(c.Args().First())
+        if err != nil {
+            return err
+        }
 
+        year := c.String("year")
+        s, err := solver.GetYearSolver(year, day)
         if err != nil {
             return err
         }
 
-        fmt.Println(res)
+        var input io.Reader = bufio.NewReader(os.Stdin)
+        if c.Bool("cached") {
+            cache := solver.Cache{Dir: c.String("cache")}
+            data, err := cache.FetchInput(ctx, year, s.Day(), c.String("session"), false)
+            if err != nil {
+                return err
+            }
+            input = bytes.NewReader(data)
+        }
+
+        if timeout := c.Duration("timeout"); timeout > 0 {
+            var cancel context.CancelFunc
+            ctx, cancel = context.WithTimeout(ctx, timeout)
+            defer cancel()
+        }
+
+        res, err := solver.Solve(s, input, ctx)
+
+        if err != nil && format == solver.FormatText {
+            return err
+        }
+
+        if werr := solver.WriteResults(os.Stdout, format, []solver.Result{res}); werr != nil {
+            return werr
+        }
+
+        return err
+    }
+}
+
+
+func inputsFlag() *cli.StringFlag {
+    return &cli.StringFlag{
+        Name: "inputs",
+        Aliases: []string{"i"},
+        Usage: "Directory containing the dayN.txt input files, in a subdirectory per year for other years",
+        EnvVars: []string{"AOC_INPUTS"},
+        Value: "inputs",
+        Required: false,
+        HasBeenSet: false,
+    }
+}
+
+func cmdRunAllFlags() []cli.Flag {
+    var flags []cli.Flag
+
+    flags = append(flags, yearFlag(), inputsFlag(), parallelFlag(), cachedFlag(), cacheFlag())
+
+    return flags
+}
+
+func cmdRunAll(ctx context.Context) cli.ActionFunc {
+    return func (c *cli.Context) error {
+        ctx = solver.WithElapsed(ctx)
+        if c.Bool("parallel") {
+            ctx = solver.WithParallel(ctx)
+        }
+
+        load := solver.DirInputs(c.String("inputs"), c.String("year"))
+        if c.Bool("cached") {
+            load = solver.Cache{Dir: c.String("cache")}.Inputs(c.String("year"))
+        }
+
+        results := solver.RunAll(ctx, c.String("year"), load)
+
+        return solver.WriteTable(os.Stdout, results)
+    }
+}
+
+
+func cmdVerifyFlags() []cli.Flag {
+    var flags []cli.Flag
+
+    record := cli.BoolFlag{
+        Name: "record",
+        Usage: "Record the current answers as known-good instead of verifying them",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    flags = append(flags, cmdRunAllFlags()...)
+    flags = append(flags, &record)
+
+    return flags
+}
+
+func cmdVerify(ctx context.Context) cli.ActionFunc {
+    return func (c *cli.Context) error {
+        if c.Bool("parallel") {
+            ctx = solver.WithParallel(ctx)
+        }
+
+        cache := solver.Cache{Dir: c.String("cache")}
+
+        load := solver.DirInputs(c.String("inputs"), c.String("year"))
+        if c.Bool("cached") {
+            load = cache.Inputs(c.String("year"))
+        }
+
+        days, err := parseDays(c)
+        if err != nil {
+            return err
+        }
+
+        verifications, err := solver.VerifyAll(ctx, c.String("year"), load, cache, days, c.Bool("record"))
+        if err != nil {
+            return err
+        }
+
+        failed := 0
+        for _, v := range verifications {
+            switch {
+                case v.Result.Status == solver.StatusError:
+                    failed += 1
+                    fmt.Printf("%v\tFAIL\t%v\n", v.Result.Name, v.Result.Err)
+                case len(v.Mismatches) > 0:
+                    failed += 1
+                    fmt.Printf("%v\tFAIL\n", v.Result.Name)
+                    for _, m := range v.Mismatches {
+                        fmt.Printf("\t%v\n", m)
+                    }
+                case c.Bool("record"):
+                    fmt.Printf("%v\tRECORDED\t%v\t%v\n", v.Result.Name, v.Known.Part1, v.Known.Part2)
+                case v.Known == solver.Answers{}:
+                    fmt.Printf("%v\tUNKNOWN\n", v.Result.Name)
+                default:
+                    fmt.Printf("%v\tOK\n", v.Result.Name)
+            }
+        }
+
+        if failed > 0 {
+            return fmt.Errorf("%d of %d days failed verification", failed, len(verifications))
+        }
 
         return nil
     }
 }
 
 
+func cmdBenchFlags() []cli.Flag {
+    var flags []cli.Flag
+
+    runs := cli.IntFlag{
+        Name: "runs",
+        Aliases: []string{"n"},
+        Usage: "Run each part at least this many times",
+        Value: 10,
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    minTime := cli.DurationFlag{
+        Name: "min-time",
+        Aliases: []string{"m"},
+        Usage: "Keep running each part for at least this long, e.g. 1s",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    output := cli.StringFlag{
+        Name: "output",
+        Aliases: []string{"o"},
+        Usage: "Write the results as JSON to this file",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    compare := cli.StringFlag{
+        Name: "compare",
+        Usage: "Compare the results with an earlier --output file",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    flags = append(flags, yearFlag(), inputsFlag(), cachedFlag(), cacheFlag())
+    flags = append(flags, &runs, &minTime, &output, &compare)
+
+    return flags
+}
+
+func cmdBench(ctx context.Context) cli.ActionFunc {
+    return func (c *cli.Context) error {
+        load := solver.DirInputs(c.String("inputs"), c.String("year"))
+        if c.Bool("cached") {
+            load = solver.Cache{Dir: c.String("cache")}.Inputs(c.String("year"))
+        }
+
+        var previous solver.BenchReport
+        if path := c.String("compare"); path != "" {
+            var err error
+            if previous, err = solver.ReadBenchReport(path); err != nil {
+                return err
+            }
+        }
+
+        opts := solver.BenchOptions{Runs: c.Int("runs"), MinDuration: c.Duration("min-time")}
+        days, err := parseDays(c)
+        if err != nil {
+            return err
+        }
+
+        report, err := solver.BenchAll(ctx, c.String("year"), load, days, opts)
+        if err != nil {
+            return err
+        }
+
+        if path := c.String("output"); path != "" {
+            if err := solver.WriteBenchReport(path, report); err != nil {
+                return err
+            }
+        }
+
+        if c.String("compare") != "" {
+            return solver.WriteComparisonTable(os.Stdout, solver.Compare(previous, report))
+        }
+
+        return solver.WriteBenchTable(os.Stdout, report)
+    }
+}
+
+
+func cmdDescribeFlags() []cli.Flag {
+    var flags []cli.Flag
+
+    refresh := cli.BoolFlag{
+        Name: "refresh",
+        Usage: "Fetch the puzzle again, even when it is cached",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    format := cli.StringFlag{
+        Name: "format",
+        Aliases: []string{"f"},
+        Usage: fmt.Sprintf("Output format, %v or %v", solver.FormatText, solver.FormatMarkdown),
+        Value: string(solver.FormatText),
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    examples := cli.StringFlag{
+        Name: "examples",
+        Usage: "Directory to store the example of the puzzle in, when it has none yet",
+        Value: "examples",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    flags = append(flags, yearFlag(), sessionFlag(), cacheFlag(), &refresh, &format, &examples)
+
+    return flags
+}
+
+func cmdDescribe(ctx context.Context) cli.ActionFunc {
+    return func (c *cli.Context) error {
+        day, err := parseDay(c.Args().First())
+        if err != nil {
+            return err
+        }
+
+        format, err := solver.ParseFormat(c.String("format"))
+        if err != nil {
+            return err
+        }
+
+        cache := solver.Cache{Dir: c.String("cache")}
+        puzzle, err := cache.FetchPuzzle(ctx, c.String("year"), day, c.String("session"), c.Bool("refresh"))
+        if err != nil {
+            return err
+        }
+
+        text, err := puzzle.Render(format)
+        if err != nil {
+            return err
+        }
+        fmt.Print(text)
+
+        if dir := c.String("examples"); dir != "" {
+            written, err := solver.WriteExample(dir, day, puzzle)
+            if err != nil {
+                return fmt.Errorf("write example: %w", err)
+            }
+            if written {
+                fmt.Fprintf(os.Stderr, "example written to %s\n", filepath.Join(dir, fmt.Sprintf("day%s.txt", day)))
+            }
+        }
+
+        return nil
+    }
+}
+
+
+func cmdNewFlags() []cli.Flag {
+    var flags []cli.Flag
+
+    solutions := cli.StringFlag{
+        Name: "solutions",
+        Usage: "Directory holding the dayN solution packages and register.go",
+        Value: filepath.Join("src", "solutions"),
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    examples := cli.StringFlag{
+        Name: "examples",
+        Usage: "Directory holding the examples to wire into the test",
+        Value: "examples",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    flags = append(flags, &solutions, &examples)
+
+    return flags
+}
+
+func cmdNew(ctx context.Context) cli.ActionFunc {
+    return func (c *cli.Context) error {
+        day, err := parseDay(c.Args().First())
+        if err != nil {
+            return err
+        }
+
+        dir := c.String("solutions")
+        if err := scaffold.New(dir, c.String("examples"), day); err != nil {
+            return err
+        }
+
+        fmt.Printf("created %s\n", filepath.Join(dir, "day" + day))
+
+        return nil
+    }
+}
+
+
+func cmdLeaderboardFlags() []cli.Flag {
+    var flags []cli.Flag
+
+    id := cli.StringFlag{
+        Name: "id",
+        Usage: "ID of the private leaderboard, when not given as argument",
+        EnvVars: []string{"AOC_LEADERBOARD"},
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    flags = append(flags, &id, yearFlag(), sessionFlag(), cacheFlag())
+
+    return flags
+}
+
+func cmdLeaderboard(ctx context.Context) cli.ActionFunc {
+    return func (c *cli.Context) error {
+        id := c.Args().First()
+        if id == "" {
+            id = c.String("id")
+        }
+        if id == "" {
+            return errors.New("no leaderboard provided")
+        }
+
+        cache := solver.Cache{Dir: c.String("cache")}
+        lb, fetched, err := cache.FetchLeaderboard(ctx, c.String("year"), id, c.String("session"))
+        if err != nil {
+            return err
+        }
+
+        next := fetched.Add(solver.LeaderboardInterval).Sub(solver.SystemClock.Now()).Round(time.Second)
+        fmt.Printf("Leaderboard %s of %s as of %s", id, c.String("year"), fetched.Format("2006-01-02 15:04:05"))
+        if next > 0 {
+            fmt.Printf(" (can be updated in %v)", next)
+        }
+        fmt.Print("\n\n")
+
+        return solver.WriteLeaderboard(os.Stdout, lb)
+    }
+}
+
+
 func cmdInputFlags() []cli.Flag {
     var flags []cli.Flag
 
-    session := cli.StringFlag{
-        Name: "session",
-        Aliases: []string{"s"},
-        Usage: "AOC Auth session token for getting inputs directly",
-        EnvVars: []string{"AOC_SESSION"},
-        Required: true,
+    refresh := cli.BoolFlag{
+        Name: "refresh",
+        Usage: "Fetch the input again, even when it is cached",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    wait := cli.BoolFlag{
+        Name: "wait",
+        Aliases: []string{"w"},
+        Usage: "Wait until the puzzle unlocks, or the next one without a day, before fetching",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    run := cli.BoolFlag{
+        Name: "run",
+        Aliases: []string{"r"},
+        Usage: "Solve the puzzle with the input instead of printing it",
+        Required: false,
+        HasBeenSet: false,
+    }
+
+    elapsed := cli.BoolFlag{
+        Name: "elapsed",
+        Aliases: []string{"e"},
+        Usage: "Shows elapsed time metric with --run",
+        Required: false,
         HasBeenSet: false,
     }
 
-    flags = append(flags, &session)
+    flags = append(flags, yearFlag(), sessionFlag(), cacheFlag(), &refresh, &wait, &run, &elapsed)
 
     return flags
 }
@@ -96,28 +621,103 @@ func cmdInput(ctx context.Context) cli.ActionFunc {
     return func (c *cli.Context) error {
 
         var sess = c.String("session")
-        if sess == "" {
-            sess = c.String("s")
 
-            if sess == "" {
-                return errors.New("no session token provided")
+        year, day := c.String("year"), c.Args().First()
+        if day == "" && c.Bool("wait") {
+            next, nextDay := solver.NextUnlock(solver.SystemClock.Now())
+            if c.IsSet("year") && next != year {
+                return fmt.Errorf("the next puzzle to unlock is %s/%s, give a day to wait for one of %s", next, nextDay, year)
+            }
+            year, day = next, nextDay
+        }
+        day, err := parseDay(day)
+        if err != nil {
+            return err
+        }
+
+        cache := solver.Cache{Dir: c.String("cache")}
+
+        var input []byte
+        if c.Bool("wait") {
+            counting := false
+            opts := solver.DefaultWaitOptions
+            opts.Countdown = func(left time.Duration) {
+                counting = true
+                fmt.Fprintf(os.Stderr, "\r[%s/%s] unlocks in %v ", year, day, left.Round(time.Second))
+            }
+            input, err = cache.WaitForInput(ctx, year, day, sess, opts)
+            if counting {
+                fmt.Fprintln(os.Stderr)
             }
+        } else {
+            input, err = cache.FetchInput(ctx, year, day, sess, c.Bool("refresh"))
         }
 
-        ctx = context.WithValue(ctx, "session", sess)
+        if err != nil {
+            return err
+        }
 
-        day := c.Args().First()
-        if day == "" {
-            return errors.New("no puzzle provided")
+        if !c.Bool("run") {
+            fmt.Print(string(input[:]))
+            return nil
         }
 
-        input, err := solver.GetInput(ctx, day, sess)
+        if c.Bool("elapsed") {
+            ctx = solver.WithElapsed(ctx)
+        }
 
+        s, err := solver.GetYearSolver(year, day)
         if err != nil {
             return err
         }
 
-        fmt.Print(string(input[:]))
+        res, err := solver.Solve(s, bytes.NewReader(input), ctx)
+        if err != nil {
+            return err
+        }
+
+        return solver.WriteResults(os.Stdout, solver.FormatText, []solver.Result{res})
+    }
+}
+
+
+func cmdSubmitFlags() []cli.Flag {
+    var flags []cli.Flag
+
+    flags = append(flags, yearFlag(), sessionFlag(), cacheFlag())
+
+    return flags
+}
+
+func cmdSubmit(ctx context.Context) cli.ActionFunc {
+    return func (c *cli.Context) error {
+        if c.Args().Len() != 3 {
+            return errors.New("expected a day, a part and an answer")
+        }
+
+        day, err := parseDay(c.Args().Get(0))
+        if err != nil {
+            return err
+        }
+        part, err := strconv.Atoi(c.Args().Get(1))
+        if err != nil {
+            return fmt.Errorf("invalid part %q: %w", c.Args().Get(1), err)
+        }
+        answer := c.Args().Get(2)
+
+        cache := solver.Cache{Dir: c.String("cache")}
+        feedback, err := solver.Submit(ctx, cache, c.String("year"), day, part, answer, c.String("session"))
+        if err != nil {
+            return err
+        }
+
+        fmt.Printf("%v\t%v\t%v\n", day, part, feedback.Outcome)
+        if feedback.Wait > 0 {
+            fmt.Printf("wait %v before submitting again\n", feedback.Wait)
+        }
+        if feedback.Outcome == solver.OutcomeUnknown {
+            fmt.Println(feedback.Message)
+        }
 
         return nil
     }
@@ -133,6 +733,28 @@ func commands(ctx context.Context) []*cli.Command {
             Flags: cmdRunFlags(),
             SkipFlagParsing: false,
         },
+        {
+            Name: "run-all",
+            Usage: `run all solutions and summarise them in a table`,
+            Action: cmdRunAll(ctx),
+            Flags: cmdRunAllFlags(),
+            SkipFlagParsing: false,
+        },
+        {
+            Name: "verify",
+            Usage: `compare answers with the recorded known-good answers`,
+            Action: cmdVerify(ctx),
+            Flags: cmdVerifyFlags(),
+            SkipFlagParsing: false,
+        },
+        {
+            Name: "bench",
+            Usage: `benchmark the solutions of the given days, or all of them`,
+            ArgsUsage: "[day...]",
+            Action: cmdBench(ctx),
+            Flags: cmdBenchFlags(),
+            SkipFlagParsing: false,
+        },
         {
             Name: "input",
             Usage: `get input for a specific day`,
@@ -140,6 +762,38 @@ func commands(ctx context.Context) []*cli.Command {
             Flags: cmdInputFlags(),
             SkipFlagParsing: false,
         },
+        {
+            Name: "leaderboard",
+            Usage: `show a private leaderboard`,
+            ArgsUsage: "[id]",
+            Action: cmdLeaderboard(ctx),
+            Flags: cmdLeaderboardFlags(),
+            SkipFlagParsing: false,
+        },
+        {
+            Name: "new",
+            Usage: `create the package for a specific day from the template`,
+            ArgsUsage: "<day>",
+            Action: cmdNew(ctx),
+            Flags: cmdNewFlags(),
+            SkipFlagParsing: false,
+        },
+        {
+            Name: "describe",
+            Usage: `show the puzzle description of a specific day`,
+            ArgsUsage: "<day>",
+            Action: cmdDescribe(ctx),
+            Flags: cmdDescribeFlags(),
+            SkipFlagParsing: false,
+        },
+        {
+            Name: "submit",
+            Usage: `submit the answer for a part of a specific day`,
+            ArgsUsage: "<day> <part> <answer>",
+            Action: cmdSubmit(ctx),
+            Flags: cmdSubmitFlags(),
+            SkipFlagParsing: false,
+        },
     }
 }
 
@@ -163,6 +817,8 @@ func main() {
 
     app.CommandNotFound = notFound(ctx)
     app.Commands = commands(ctx)
+    app.Flags = []cli.Flag{userAgentFlag()}
+    app.Before = onStart(ctx)
     app.After = onExit(ctx)
 
     if err := app.Run(os.Args); err != nil {
@@ -0,0 +1,100 @@
+package pathfinding
+
+import (
+	"fmt"
+
+	"github.com/wthys/advent-of-code-2023/collections/pqueue"
+	"github.com/wthys/advent-of-code-2023/location"
+)
+
+type (
+	// `HeuristicFunc` estimates the remaining cost from `node` to the goal. To
+	// guarantee a shortest path, it must never overestimate.
+	HeuristicFunc[T comparable] func(node T) int
+
+	// `SearchStats` reports how much work a search did.
+	SearchStats struct {
+		Expanded    int
+		MaxFrontier int
+	}
+
+	estimated[T comparable] struct {
+		node     T
+		cost     int
+		estimate int
+	}
+)
+
+// `ZeroHeuristic` never estimates any remaining cost, turning `AStar` into
+// Dijkstra's algorithm.
+func ZeroHeuristic[T comparable]() HeuristicFunc[T] {
+	return func(_ T) int {
+		return 0
+	}
+}
+
+// `ManhattanTo` estimates the remaining cost as the Manhattan distance to
+// `target`.
+func ManhattanTo(target location.Location) HeuristicFunc[location.Location] {
+	return func(node location.Location) int {
+		return target.Subtract(node).Manhattan()
+	}
+}
+
+// `ManhattanTo3` estimates the remaining cost as the Manhattan distance to
+// `target`.
+func ManhattanTo3(target location.Location3) HeuristicFunc[location.Location3] {
+	return func(node location.Location3) int {
+		return target.Subtract(node).Manhattan()
+	}
+}
+
+// `AStar` searches for the cheapest path from `start` to any node for which
+// `goal` returns true. The returned path excludes `start` and ends at the goal
+// node, just like `ShortestPath`. Returns an error when no goal can be reached.
+func AStar[T comparable](start T, goal ExitFunc[T], neejbers WeightedNeejberFunc[T], heuristic HeuristicFunc[T]) ([]T, int, SearchStats, error) {
+	stats := SearchStats{}
+	dist := DistMap[T]{}
+	prev := PrevMap[T]{}
+
+	prev[start] = nil
+	dist[start] = 0
+	frontier := pqueue.New(func(a, b estimated[T]) bool {
+		if a.estimate == b.estimate {
+			return a.cost > b.cost
+		}
+		return a.estimate < b.estimate
+	}, estimated[T]{start, 0, heuristic(start)})
+	stats.MaxFrontier = 1
+
+	for frontier.Len() > 0 {
+		item, _ := frontier.Pop()
+		node := item.node
+		if item.cost > dist[node] {
+			continue
+		}
+
+		if goal(node) {
+			path := SimpleDijkstra[T]{start, dist, prev}.ShortestPathTo(node)
+			return path, item.cost, stats, nil
+		}
+		stats.Expanded += 1
+
+		for _, edge := range neejbers(node) {
+			if edge.Cost < 0 {
+				return nil, INFINITE, stats, fmt.Errorf("negative cost %v from %v to %v", edge.Cost, node, edge.Node)
+			}
+			alt := item.cost + edge.Cost
+			ndist, ok := dist[edge.Node]
+			if !ok || alt < ndist {
+				from := node
+				dist[edge.Node] = alt
+				prev[edge.Node] = &from
+				frontier.Push(estimated[T]{edge.Node, alt, alt + heuristic(edge.Node)})
+				stats.MaxFrontier = max(stats.MaxFrontier, frontier.Len())
+			}
+		}
+	}
+
+	return nil, INFINITE, stats, fmt.Errorf("could not find a path from %v to a goal", start)
+}
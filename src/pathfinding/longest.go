@@ -0,0 +1,237 @@
+package pathfinding
+
+import (
+	"fmt"
+)
+
+type (
+	// `explored` is the part of a graph reachable from a start node, with
+	// nodes replaced by their index for fast lookups.
+	explored[T comparable] struct {
+		nodes []T
+		index map[T]int
+		edges [][]indexedEdge
+	}
+
+	indexedEdge struct {
+		to   int
+		cost int
+	}
+)
+
+func explore[T comparable](start T, neejbers WeightedNeejberFunc[T]) explored[T] {
+	graph := explored[T]{[]T{start}, map[T]int{start: 0}, [][]indexedEdge{}}
+
+	for idx := 0; idx < len(graph.nodes); idx++ {
+		edges := []indexedEdge{}
+		for _, edge := range neejbers(graph.nodes[idx]) {
+			to, ok := graph.index[edge.Node]
+			if !ok {
+				to = len(graph.nodes)
+				graph.index[edge.Node] = to
+				graph.nodes = append(graph.nodes, edge.Node)
+			}
+			edges = append(edges, indexedEdge{to, edge.Cost})
+		}
+		graph.edges = append(graph.edges, edges)
+	}
+
+	return graph
+}
+
+func (g explored[T]) path(indices []int) []T {
+	path := []T{}
+	for _, idx := range indices {
+		path = append(path, g.nodes[idx])
+	}
+	return path
+}
+
+// `LongestPathDAG` finds the most expensive path from `start` to `end` in a
+// directed acyclic graph in linear time. The returned path excludes `start`.
+// Returns an error when `end` is unreachable or a cycle is found.
+func LongestPathDAG[T comparable](start, end T, neejbers WeightedNeejberFunc[T]) ([]T, int, error) {
+	graph := explore(start, neejbers)
+
+	target, ok := graph.index[end]
+	if !ok {
+		return nil, -INFINITE, fmt.Errorf("could not find a path from %v to %v", start, end)
+	}
+
+	const (
+		unseen = iota
+		active
+		done
+	)
+
+	state := make([]int, len(graph.nodes))
+	order := []int{}
+
+	var visit func(idx int) error
+	visit = func(idx int) error {
+		state[idx] = active
+		for _, edge := range graph.edges[idx] {
+			switch state[edge.to] {
+			case active:
+				return fmt.Errorf("graph has a cycle through %v", graph.nodes[edge.to])
+			case unseen:
+				if err := visit(edge.to); err != nil {
+					return err
+				}
+			}
+		}
+		state[idx] = done
+		order = append(order, idx)
+		return nil
+	}
+
+	if err := visit(0); err != nil {
+		return nil, -INFINITE, err
+	}
+
+	dist := make([]int, len(graph.nodes))
+	prev := make([]int, len(graph.nodes))
+	for idx := range dist {
+		dist[idx] = -INFINITE
+		prev[idx] = -1
+	}
+	dist[0] = 0
+
+	for i := len(order) - 1; i >= 0; i-- {
+		idx := order[i]
+		if dist[idx] == -INFINITE {
+			continue
+		}
+		for _, edge := range graph.edges[idx] {
+			if alt := dist[idx] + edge.cost; alt > dist[edge.to] {
+				dist[edge.to] = alt
+				prev[edge.to] = idx
+			}
+		}
+	}
+
+	indices := []int{}
+	for idx := target; idx != 0; idx = prev[idx] {
+		indices = append([]int{idx}, indices...)
+	}
+
+	return graph.path(indices), dist[target], nil
+}
+
+// `LongestPath` finds the most expensive simple path from `start` to `end` in
+// any graph, using a depth-first search that prunes branches that cannot beat
+// the best path found so far. The search is exponential in the worst case, so
+// compress the graph first with `Compress` where possible. The returned path
+// excludes `start`. Returns an error when `end` is unreachable.
+func LongestPath[T comparable](start, end T, neejbers WeightedNeejberFunc[T]) ([]T, int, error) {
+	graph := explore(start, neejbers)
+
+	target, ok := graph.index[end]
+	if !ok {
+		return nil, -INFINITE, fmt.Errorf("could not find a path from %v to %v", start, end)
+	}
+
+	// every node can contribute at most its most expensive outgoing edge
+	potential := make([]int, len(graph.nodes))
+	remaining := 0
+	for idx, edges := range graph.edges {
+		for _, edge := range edges {
+			potential[idx] = max(potential[idx], edge.cost)
+		}
+		remaining += potential[idx]
+	}
+
+	visited := make([]bool, len(graph.nodes))
+	stack := []int{}
+	best := -INFINITE
+	bestPath := []int(nil)
+
+	var search func(idx, cost int)
+	search = func(idx, cost int) {
+		if idx == target {
+			if cost > best {
+				best = cost
+				bestPath = append([]int{}, stack...)
+			}
+			return
+		}
+		if cost+remaining <= best {
+			return
+		}
+
+		visited[idx] = true
+		remaining -= potential[idx]
+		for _, edge := range graph.edges[idx] {
+			if visited[edge.to] {
+				continue
+			}
+			stack = append(stack, edge.to)
+			search(edge.to, cost+edge.cost)
+			stack = stack[:len(stack)-1]
+		}
+		remaining += potential[idx]
+		visited[idx] = false
+	}
+
+	search(0, 0)
+
+	if bestPath == nil {
+		return nil, -INFINITE, fmt.Errorf("could not find a path from %v to %v", start, end)
+	}
+
+	return graph.path(bestPath), best, nil
+}
+
+// `Compress` collapses corridors of nodes with exactly two neejbers into single
+// weighted edges between junctions. A junction is a node with more than two
+// neejbers, a node where a corridor forks, or one of the `keep` nodes (usually
+// the start and end of a search). Corridors leading to dead ends are dropped.
+// The resulting `WeightedNeejberFunc` should only be called for junctions.
+func Compress[T comparable](neejbers NeejberFunc[T], keep ...T) WeightedNeejberFunc[T] {
+	kept := map[T]bool{}
+	for _, node := range keep {
+		kept[node] = true
+	}
+
+	cache := map[T][]Edge[T]{}
+
+	return func(junction T) []Edge[T] {
+		if edges, ok := cache[junction]; ok {
+			return edges
+		}
+
+		edges := []Edge[T]{}
+		for _, first := range neejbers(junction) {
+			prev := junction
+			node := first
+			cost := 1
+			for {
+				if node == junction {
+					break
+				}
+
+				next := []T{}
+				for _, neejber := range neejbers(node) {
+					if neejber != prev {
+						next = append(next, neejber)
+					}
+				}
+
+				if kept[node] || len(next) > 1 {
+					edges = append(edges, Edge[T]{node, cost})
+					break
+				}
+				if len(next) == 0 {
+					break
+				}
+
+				prev = node
+				node = next[0]
+				cost += 1
+			}
+		}
+
+		cache[junction] = edges
+		return edges
+	}
+}
@@ -0,0 +1,174 @@
+package pathfinding
+
+type (
+	// `MultiSource` holds the distances from the closest of several start
+	// nodes. It implements `Dijkstra`, with paths starting at the start node
+	// that reached the end first.
+	MultiSource[T comparable] struct {
+		dist   DistMap[T]
+		prev   PrevMap[T]
+		source map[T]T
+	}
+
+	// `DistanceTable` holds the shortest distances between every pair of a
+	// set of nodes of interest.
+	DistanceTable[T comparable] struct {
+		nodes []T
+		dist  map[T]DistMap[T]
+	}
+)
+
+// `MultiSourceBFS` explores breadth-first from all `starts` at once. The
+// search stops as soon as one of the `exitters` returns true for a node.
+func MultiSourceBFS[T comparable](starts []T, neejbers NeejberFunc[T], exitters ...ExitFunc[T]) MultiSource[T] {
+	dist := DistMap[T]{}
+	prev := PrevMap[T]{}
+	source := map[T]T{}
+
+	queue := []T{}
+	for _, start := range starts {
+		if _, seen := dist[start]; seen {
+			continue
+		}
+		prev[start] = nil
+		dist[start] = 0
+		source[start] = start
+		queue = append(queue, start)
+	}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		stop := false
+		for _, exit := range exitters {
+			if exit(node) {
+				stop = true
+			}
+		}
+		if stop {
+			break
+		}
+
+		for _, neejber := range neejbers(node) {
+			if _, seen := dist[neejber]; seen {
+				continue
+			}
+			from := node
+			dist[neejber] = dist[node] + 1
+			prev[neejber] = &from
+			source[neejber] = source[node]
+			queue = append(queue, neejber)
+		}
+	}
+
+	return MultiSource[T]{dist, prev, source}
+}
+
+// `MultiSourceDijkstra` runs Dijkstra's algorithm from all `starts` at once.
+// The search stops as soon as one of the `exitters` returns true for a settled
+// node.
+func MultiSourceDijkstra[T comparable](starts []T, neejbers WeightedNeejberFunc[T], exitters ...ExitFunc[T]) MultiSource[T] {
+	dist, prev, source := settle(starts, neejbers, exitters...)
+	return MultiSource[T]{dist, prev, source}
+}
+
+// `SourceOf` returns the start node that reached `node` first.
+func (m MultiSource[T]) SourceOf(node T) (T, bool) {
+	source, ok := m.source[node]
+	return source, ok
+}
+
+func (m MultiSource[T]) ShortestPathTo(end T) []T {
+	if _, ok := m.dist[end]; !ok {
+		return nil
+	}
+
+	path := []T{}
+	for node := &end; m.prev[*node] != nil; node = m.prev[*node] {
+		path = append([]T{*node}, path...)
+	}
+	return path
+}
+
+func (m MultiSource[T]) ShortestPathLengthTo(end T) int {
+	dist, ok := m.dist[end]
+	if !ok {
+		return INFINITE
+	}
+	return dist
+}
+
+func (m MultiSource[T]) ForEachNode(forEach func(node T) bool) {
+	for node := range m.dist {
+		if !forEach(node) {
+			return
+		}
+	}
+}
+
+// `ReachableIn` counts the nodes that can be reached in exactly `steps` steps
+// when moving back and forth is allowed, i.e. the nodes that are at most
+// `steps` away and have the same parity.
+func (m MultiSource[T]) ReachableIn(steps int) int {
+	total := 0
+	for _, dist := range m.dist {
+		if dist <= steps && dist%2 == steps%2 {
+			total += 1
+		}
+	}
+	return total
+}
+
+// `AllPairs` calculates the shortest distances between every pair of `nodes`.
+// Each node runs a search that stops once all other `nodes` have been settled,
+// so this is meant for a small number of nodes of interest.
+func AllPairs[T comparable](nodes []T, neejbers WeightedNeejberFunc[T]) DistanceTable[T] {
+	table := DistanceTable[T]{nodes, map[T]DistMap[T]{}}
+
+	for _, from := range nodes {
+		wanted := map[T]bool{}
+		for _, to := range nodes {
+			wanted[to] = true
+		}
+
+		dist, _, _ := settle([]T{from}, neejbers, func(node T) bool {
+			delete(wanted, node)
+			return len(wanted) == 0
+		})
+
+		row := DistMap[T]{}
+		for _, to := range nodes {
+			if d, ok := dist[to]; ok {
+				row[to] = d
+			}
+		}
+		table.dist[from] = row
+	}
+
+	return table
+}
+
+// `Get` returns the shortest distance from `a` to `b`, or `INFINITE` when
+// either is unknown or `b` cannot be reached.
+func (t DistanceTable[T]) Get(a, b T) int {
+	row, ok := t.dist[a]
+	if !ok {
+		return INFINITE
+	}
+	dist, ok := row[b]
+	if !ok {
+		return INFINITE
+	}
+	return dist
+}
+
+// `ForEachPair` calls `forEach` once for every unordered pair of distinct
+// nodes, in the order the nodes were given.
+func (t DistanceTable[T]) ForEachPair(forEach func(a, b T, dist int)) {
+	for idx, a := range t.nodes {
+		for _, b := range t.nodes[idx+1:] {
+			forEach(a, b, t.Get(a, b))
+		}
+	}
+}
@@ -3,6 +3,7 @@ package pathfinding
 import (
 	"fmt"
 
+	"github.com/wthys/advent-of-code-2023/collections/pqueue"
 	"github.com/wthys/advent-of-code-2023/collections/set"
 )
 
@@ -24,6 +25,19 @@ type (
 
 	NeejberFunc[T comparable] func(node T) []T
 	ExitFunc[T comparable]    func(node T) bool
+
+	// `Edge` is a weighted connection to `Node`.
+	Edge[T comparable] struct {
+		Node T
+		Cost int
+	}
+
+	WeightedNeejberFunc[T comparable] func(node T) []Edge[T]
+
+	queued[T comparable] struct {
+		node T
+		dist int
+	}
 )
 
 const (
@@ -31,20 +45,57 @@ const (
 )
 
 func ControlledDijkstra[T comparable](start T, neejbers NeejberFunc[T], exitters ...ExitFunc[T]) Dijkstra[T] {
+	return ControlledWeightedDijkstra(start, UnitCost(neejbers), exitters...)
+}
+
+func ConstructDijkstra[T comparable](start T, neejbers NeejberFunc[T]) Dijkstra[T] {
+	return ConstructWeightedDijkstra(start, UnitCost(neejbers))
+}
+
+// `UnitCost` turns a `NeejberFunc` into a `WeightedNeejberFunc` where every
+// edge costs 1.
+func UnitCost[T comparable](neejbers NeejberFunc[T]) WeightedNeejberFunc[T] {
+	return func(node T) []Edge[T] {
+		edges := []Edge[T]{}
+		for _, neejber := range neejbers(node) {
+			edges = append(edges, Edge[T]{neejber, 1})
+		}
+		return edges
+	}
+}
+
+// `ControlledWeightedDijkstra` runs Dijkstra's algorithm from `start` over the
+// edges produced by `neejbers`. The search stops as soon as one of the
+// `exitters` returns true for a settled node. Costs must not be negative.
+func ControlledWeightedDijkstra[T comparable](start T, neejbers WeightedNeejberFunc[T], exitters ...ExitFunc[T]) Dijkstra[T] {
+	dist, prev, _ := settle([]T{start}, neejbers, exitters...)
+	return SimpleDijkstra[T]{start, dist, prev}
+}
+
+// `settle` runs Dijkstra's algorithm from all `starts` at once, keeping track
+// of which start reached every node.
+func settle[T comparable](starts []T, neejbers WeightedNeejberFunc[T], exitters ...ExitFunc[T]) (DistMap[T], PrevMap[T], map[T]T) {
 	dist := DistMap[T]{}
 	prev := PrevMap[T]{}
+	source := map[T]T{}
 	visited := set.New[T]()
 
-	prev[start] = nil
-	dist[start] = 0
-	queue := set.New(start)
+	queue := pqueue.New(func(a, b queued[T]) bool {
+		return a.dist < b.dist
+	})
+	for _, start := range starts {
+		prev[start] = nil
+		dist[start] = 0
+		source[start] = start
+		queue.Push(queued[T]{start, 0})
+	}
 
 	for queue.Len() > 0 {
-		node, err := closest(queue, dist)
-		if err != nil {
-			panic(err)
+		item, _ := queue.Pop()
+		node := item.node
+		if visited.Has(node) || item.dist > dist[node] {
+			continue
 		}
-		queue = queue.Remove(node)
 		visited.Add(node)
 
 		stop := false
@@ -57,56 +108,32 @@ func ControlledDijkstra[T comparable](start T, neejbers NeejberFunc[T], exitters
 			break
 		}
 
-		for _, neejber := range neejbers(node) {
-			if visited.Has(neejber) {
+		for _, edge := range neejbers(node) {
+			if edge.Cost < 0 {
+				panic(fmt.Errorf("negative cost %v from %v to %v", edge.Cost, node, edge.Node))
+			}
+			if visited.Has(edge.Node) {
 				continue
 			}
-			queue.Add(neejber)
-			alt := dist[node] + 1
-			ndist, ok := dist[neejber]
+			alt := dist[node] + edge.Cost
+			ndist, ok := dist[edge.Node]
 			if !ok || alt < ndist {
-				dist[neejber] = alt
-				prev[neejber] = &node
+				from := node
+				dist[edge.Node] = alt
+				prev[edge.Node] = &from
+				source[edge.Node] = source[node]
+				queue.Push(queued[T]{edge.Node, alt})
 			}
 		}
 	}
 
-	return SimpleDijkstra[T]{start, dist, prev}
-
+	return dist, prev, source
 }
 
-func ConstructDijkstra[T comparable](start T, neejbers NeejberFunc[T]) Dijkstra[T] {
-	dist := DistMap[T]{}
-	prev := PrevMap[T]{}
-	visited := set.New[T]()
-
-	prev[start] = nil
-	dist[start] = 0
-	queue := set.New(start)
-
-	for queue.Len() > 0 {
-		node, err := closest(queue, dist)
-		if err != nil {
-			panic(err)
-		}
-		queue = queue.Remove(node)
-		visited.Add(node)
-
-		for _, neejber := range neejbers(node) {
-			if visited.Has(neejber) {
-				continue
-			}
-			queue.Add(neejber)
-			alt := dist[node] + 1
-			ndist, ok := dist[neejber]
-			if !ok || alt < ndist {
-				dist[neejber] = alt
-				prev[neejber] = &node
-			}
-		}
-	}
-
-	return SimpleDijkstra[T]{start, dist, prev}
+// `ConstructWeightedDijkstra` runs Dijkstra's algorithm from `start` until
+// every reachable node has been settled.
+func ConstructWeightedDijkstra[T comparable](start T, neejbers WeightedNeejberFunc[T]) Dijkstra[T] {
+	return ControlledWeightedDijkstra(start, neejbers)
 }
 
 func (d SimpleDijkstra[T]) ShortestPathTo(end T) []T {
@@ -155,23 +182,3 @@ func ShortestPath[T comparable](start, end T, neejbers NeejberFunc[T]) ([]T, err
 
 	return path, nil
 }
-
-func closest[T comparable](Q *set.Set[T], dist DistMap[T]) (T, error) {
-	shortest := INFINITE
-	snode := *new(T)
-	found := false
-	Q.ForEach(func(node T) {
-		d := dist[node]
-		if d < shortest {
-			shortest = d
-			snode = node
-			found = true
-		}
-	})
-
-	if !found {
-		return snode, fmt.Errorf("could not find closest node")
-	}
-
-	return snode, nil
-}
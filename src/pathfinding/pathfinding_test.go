@@ -0,0 +1,427 @@
+package pathfinding
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wthys/advent-of-code-2023/grid"
+	"github.com/wthys/advent-of-code-2023/location"
+)
+
+type (
+	TestCase[I any, E any] struct {
+		input    I
+		expected E
+	}
+)
+
+var (
+	weightedGraph = map[string][]Edge[string]{
+		"A": {{"B", 7}, {"C", 9}, {"F", 14}},
+		"B": {{"A", 7}, {"C", 10}, {"D", 15}},
+		"C": {{"A", 9}, {"B", 10}, {"D", 11}, {"F", 2}},
+		"D": {{"B", 15}, {"C", 11}, {"E", 6}},
+		"E": {{"D", 6}, {"F", 9}},
+		"F": {{"A", 14}, {"C", 2}, {"E", 9}},
+		"G": {},
+	}
+)
+
+func weightedNeejbers(node string) []Edge[string] {
+	return weightedGraph[node]
+}
+
+func TestConstructWeightedDijkstra(t *testing.T) {
+	testcases := []TestCase[string, int]{
+		{"A", 0},
+		{"B", 7},
+		{"C", 9},
+		{"D", 20},
+		{"E", 20},
+		{"F", 11},
+		{"G", INFINITE},
+	}
+
+	d := ConstructWeightedDijkstra("A", weightedNeejbers)
+
+	for _, tc := range testcases {
+		actual := d.ShortestPathLengthTo(tc.input)
+		if actual != tc.expected {
+			t.Fatalf("ShortestPathLengthTo(%v) = %v, want %v", tc.input, actual, tc.expected)
+		}
+	}
+
+	path := d.ShortestPathTo("E")
+	want := []string{"C", "F", "E"}
+	if !slices.Equal(path, want) {
+		t.Fatalf("ShortestPathTo(E) = %v, want %v", path, want)
+	}
+
+	if path := d.ShortestPathTo("G"); path != nil {
+		t.Fatalf("ShortestPathTo(G) = %v, want nil", path)
+	}
+}
+
+func TestControlledWeightedDijkstra(t *testing.T) {
+	d := ControlledWeightedDijkstra("A", weightedNeejbers, func(node string) bool {
+		return node == "C"
+	})
+
+	if actual := d.ShortestPathLengthTo("C"); actual != 9 {
+		t.Fatalf("ShortestPathLengthTo(C) = %v, want %v", actual, 9)
+	}
+
+	if actual := d.ShortestPathLengthTo("E"); actual != INFINITE {
+		t.Fatalf("ShortestPathLengthTo(E) = %v, want %v (search should have stopped)", actual, INFINITE)
+	}
+}
+
+func TestShortestPathGrid(t *testing.T) {
+	bound := 10
+	neejbers := func(loc location.Location) []location.Location {
+		result := []location.Location{}
+		for _, n := range loc.OrthoNeejbers() {
+			if n.X >= 0 && n.Y >= 0 && n.X < bound && n.Y < bound {
+				result = append(result, n)
+			}
+		}
+		return result
+	}
+
+	start := location.New(0, 0)
+	end := location.New(bound-1, bound-1)
+
+	path, err := ShortestPath(start, end, neejbers)
+	if err != nil {
+		t.Fatalf("ShortestPath(%v, %v) = %v, want a path", start, end, err)
+	}
+
+	if len(path) != end.Subtract(start).Manhattan() {
+		t.Fatalf("len(ShortestPath(%v, %v)) = %v, want %v", start, end, len(path), end.Subtract(start).Manhattan())
+	}
+
+	if path[len(path)-1] != end {
+		t.Fatalf("ShortestPath(%v, %v) ends in %v, want %v", start, end, path[len(path)-1], end)
+	}
+}
+
+func gridNeejbers(bound int, walls ...location.Location) WeightedNeejberFunc[location.Location] {
+	blocked := map[location.Location]bool{}
+	for _, wall := range walls {
+		blocked[wall] = true
+	}
+	return func(loc location.Location) []Edge[location.Location] {
+		result := []Edge[location.Location]{}
+		for _, n := range loc.OrthoNeejbers() {
+			if n.X >= 0 && n.Y >= 0 && n.X < bound && n.Y < bound && !blocked[n] {
+				result = append(result, Edge[location.Location]{n, 1})
+			}
+		}
+		return result
+	}
+}
+
+func TestAStar(t *testing.T) {
+	walls := []location.Location{}
+	for y := 0; y < 15; y++ {
+		walls = append(walls, location.New(10, y))
+	}
+	neejbers := gridNeejbers(20, walls...)
+
+	start := location.New(0, 0)
+	end := location.New(19, 0)
+	goal := func(loc location.Location) bool { return loc == end }
+
+	path, cost, stats, err := AStar(start, goal, neejbers, ManhattanTo(end))
+	if err != nil {
+		t.Fatalf("AStar(%v, %v) = %v, want a path", start, end, err)
+	}
+
+	d := ConstructWeightedDijkstra(start, neejbers)
+	want := d.ShortestPathLengthTo(end)
+	if cost != want || len(path) != want {
+		t.Fatalf("AStar(%v, %v) cost %v (path len %v), want %v", start, end, cost, len(path), want)
+	}
+
+	_, _, zeroStats, _ := AStar(start, goal, neejbers, ZeroHeuristic[location.Location]())
+	if stats.Expanded > zeroStats.Expanded {
+		t.Fatalf("AStar with Manhattan expanded %v nodes, more than %v without heuristic", stats.Expanded, zeroStats.Expanded)
+	}
+	if stats.MaxFrontier == 0 {
+		t.Fatalf("AStar reported an empty frontier: %+v", stats)
+	}
+}
+
+func TestAStarUnreachable(t *testing.T) {
+	end := location.New(100, 100)
+	_, cost, _, err := AStar(location.New(0, 0), func(loc location.Location) bool {
+		return loc == end
+	}, gridNeejbers(5), ManhattanTo(end))
+
+	if err == nil || cost != INFINITE {
+		t.Fatalf("AStar to unreachable %v = %v, %v, want %v, error", end, cost, err, INFINITE)
+	}
+}
+
+func TestManhattanTo3(t *testing.T) {
+	h := ManhattanTo3(location.New3(1, 2, 3))
+	if actual := h(location.New3(-1, 5, 3)); actual != 5 {
+		t.Fatalf("ManhattanTo3((1,2,3))((-1,5,3)) = %v, want %v", actual, 5)
+	}
+}
+
+func TestLongestPathDAG(t *testing.T) {
+	dag := map[string][]Edge[string]{
+		"S": {{"A", 1}, {"B", 2}},
+		"A": {{"C", 5}, {"E", 1}},
+		"B": {{"C", 1}, {"D", 4}},
+		"C": {{"E", 3}},
+		"D": {{"E", 1}},
+	}
+
+	path, cost, err := LongestPathDAG("S", "E", func(node string) []Edge[string] {
+		return dag[node]
+	})
+	if err != nil {
+		t.Fatalf("LongestPathDAG(S, E) = %v, want a path", err)
+	}
+
+	want := []string{"A", "C", "E"}
+	if cost != 9 || !slices.Equal(path, want) {
+		t.Fatalf("LongestPathDAG(S, E) = %v, %v, want %v, %v", path, cost, want, 9)
+	}
+}
+
+func TestLongestPathDAGCycle(t *testing.T) {
+	_, _, err := LongestPathDAG("A", "C", func(node string) []Edge[string] {
+		return weightedGraph[node]
+	})
+	if err == nil {
+		t.Fatalf("LongestPathDAG on a cyclic graph should fail")
+	}
+}
+
+func TestLongestPath(t *testing.T) {
+	path, cost, err := LongestPath("A", "E", weightedNeejbers)
+	if err != nil {
+		t.Fatalf("LongestPath(A, E) = %v, want a path", err)
+	}
+
+	// A -F-> 14, F -C-> 2, C -B-> 10, B -D-> 15, D -E-> 6
+	want := []string{"F", "C", "B", "D", "E"}
+	if cost != 47 || !slices.Equal(path, want) {
+		t.Fatalf("LongestPath(A, E) = %v, %v, want %v, %v", path, cost, want, 47)
+	}
+
+	if _, _, err := LongestPath("A", "G", weightedNeejbers); err == nil {
+		t.Fatalf("LongestPath(A, G) should fail, G is unreachable")
+	}
+}
+
+func TestCompress(t *testing.T) {
+	// a ring around a 5x5 square, with a dead end poking inwards at (2,1)
+	ring := func(loc location.Location) []location.Location {
+		result := []location.Location{}
+		for _, n := range loc.OrthoNeejbers() {
+			if n.X < 0 || n.Y < 0 || n.X > 4 || n.Y > 4 {
+				continue
+			}
+			if n.X == 0 || n.Y == 0 || n.X == 4 || n.Y == 4 || n == location.New(2, 1) {
+				result = append(result, n)
+			}
+		}
+		return result
+	}
+
+	start := location.New(0, 0)
+	end := location.New(4, 4)
+	compressed := Compress(ring, start, end)
+
+	junction := location.New(2, 0)
+	want := map[location.Location]int{junction: 2, end: 8}
+
+	edges := compressed(start)
+	if len(edges) != len(want) {
+		t.Fatalf("Compress(...)(%v) = %v, want %v", start, edges, want)
+	}
+	for _, edge := range edges {
+		if cost, ok := want[edge.Node]; !ok || cost != edge.Cost {
+			t.Fatalf("Compress(...)(%v) = %v, want %v", start, edges, want)
+		}
+	}
+
+	_, cost, err := LongestPath(start, end, compressed)
+	if err != nil || cost != 8 {
+		t.Fatalf("LongestPath over compressed ring = %v, %v, want %v", cost, err, 8)
+	}
+}
+
+var crucibleCity = []string{
+	"2413432311323",
+	"3215453535623",
+	"3255245654254",
+	"3446585845452",
+	"4546657867536",
+	"1438598798454",
+	"4457876987766",
+	"3637877979653",
+	"4654967986887",
+	"4564679986453",
+	"1224686865563",
+	"2546548887735",
+	"4322674655533",
+}
+
+func TestGridStateNeejbers(t *testing.T) {
+	city := grid.New[int]()
+	for y, line := range crucibleCity {
+		for x, char := range line {
+			city.Set(location.New(x, y), int(char-'0'))
+		}
+	}
+	bounds, _ := city.Bounds()
+	start := NewState(location.New(bounds.Xmin, bounds.Ymin), location.New(0, 0))
+	end := location.New(bounds.Xmax, bounds.Ymax)
+	manhattan := ManhattanTo(end)
+
+	testcases := []TestCase[MoveRules, int]{
+		{MoveRules{0, 3, false}, 102},
+		{MoveRules{4, 10, false}, 94},
+	}
+
+	for _, tc := range testcases {
+		neejbers := GridStateNeejbers(city, tc.input, GridCost())
+		_, cost, _, err := AStar(start, ReachedGoal(end, tc.input), neejbers, func(s State) int {
+			return manhattan(s.Pos)
+		})
+		if err != nil || cost != tc.expected {
+			t.Fatalf("heat loss with %+v = %v, %v, want %v", tc.input, cost, err, tc.expected)
+		}
+	}
+}
+
+func TestMoveRulesDirections(t *testing.T) {
+	east := location.New(1, 0)
+	rules := MoveRules{2, 3, false}
+
+	testcases := []TestCase[State, []location.Location]{
+		{State{location.New(0, 0), location.New(0, 0), 0}, orthogonal},
+		{State{location.New(1, 0), east, 1}, []location.Location{east}},
+		{State{location.New(2, 0), east, 2}, []location.Location{east, location.New(0, -1), location.New(0, 1)}},
+		{State{location.New(3, 0), east, 3}, []location.Location{location.New(0, -1), location.New(0, 1)}},
+	}
+
+	for _, tc := range testcases {
+		actual := rules.Directions(tc.input)
+		if !slices.Equal(actual, tc.expected) {
+			t.Fatalf("%+v.Directions(%v) = %v, want %v", rules, tc.input, actual, tc.expected)
+		}
+	}
+}
+
+var gardenMap = []string{
+	"...........",
+	".....###.#.",
+	".###.##..#.",
+	"..#.#...#..",
+	"....#.#....",
+	".##..S####.",
+	".##..#...#.",
+	".......##..",
+	".##.#.####.",
+	".##..##.##.",
+	"...........",
+}
+
+func TestMultiSourceBFSReachableIn(t *testing.T) {
+	garden := grid.New[rune]()
+	start := location.New(0, 0)
+	for y, line := range gardenMap {
+		for x, char := range line {
+			loc := location.New(x, y)
+			if char == 'S' {
+				start = loc
+			}
+			garden.Set(loc, char)
+		}
+	}
+
+	neejbers := func(loc location.Location) []location.Location {
+		result := []location.Location{}
+		for _, n := range loc.OrthoNeejbers() {
+			if plot, err := garden.Get(n); err == nil && plot != '#' {
+				result = append(result, n)
+			}
+		}
+		return result
+	}
+
+	m := MultiSourceBFS([]location.Location{start}, neejbers)
+	if actual := m.ReachableIn(6); actual != 16 {
+		t.Fatalf("ReachableIn(6) = %v, want %v", actual, 16)
+	}
+}
+
+func TestMultiSource(t *testing.T) {
+	neejbers := gridNeejbers(10)
+	left := location.New(0, 5)
+	right := location.New(9, 5)
+	starts := []location.Location{left, right}
+
+	bfs := MultiSourceBFS(starts, func(loc location.Location) []location.Location {
+		result := []location.Location{}
+		for _, edge := range neejbers(loc) {
+			result = append(result, edge.Node)
+		}
+		return result
+	})
+	dijkstra := MultiSourceDijkstra(starts, neejbers)
+
+	testcases := []TestCase[location.Location, location.Location]{
+		{location.New(1, 1), left},
+		{location.New(3, 9), left},
+		{location.New(6, 0), right},
+		{location.New(8, 8), right},
+	}
+
+	for _, m := range []MultiSource[location.Location]{bfs, dijkstra} {
+		for _, tc := range testcases {
+			source, ok := m.SourceOf(tc.input)
+			if !ok || source != tc.expected {
+				t.Fatalf("SourceOf(%v) = %v, %v, want %v", tc.input, source, ok, tc.expected)
+			}
+
+			want := tc.input.Subtract(tc.expected).Manhattan()
+			if actual := m.ShortestPathLengthTo(tc.input); actual != want {
+				t.Fatalf("ShortestPathLengthTo(%v) = %v, want %v", tc.input, actual, want)
+			}
+			if path := m.ShortestPathTo(tc.input); len(path) != want || path[len(path)-1] != tc.input {
+				t.Fatalf("ShortestPathTo(%v) = %v, want %v steps", tc.input, path, want)
+			}
+		}
+	}
+}
+
+func TestAllPairs(t *testing.T) {
+	galaxies := []location.Location{
+		location.New(3, 0), location.New(7, 1), location.New(0, 2), location.New(6, 4),
+	}
+
+	table := AllPairs(galaxies, gridNeejbers(10))
+
+	pairs := 0
+	table.ForEachPair(func(a, b location.Location, dist int) {
+		pairs += 1
+		if want := a.Subtract(b).Manhattan(); dist != want {
+			t.Fatalf("distance %v - %v = %v, want %v", a, b, dist, want)
+		}
+	})
+
+	if pairs != 6 {
+		t.Fatalf("ForEachPair visited %v pairs, want %v", pairs, 6)
+	}
+
+	if actual := table.Get(galaxies[0], location.New(5, 5)); actual != INFINITE {
+		t.Fatalf("Get(%v, (5,5)) = %v, want %v", galaxies[0], actual, INFINITE)
+	}
+}
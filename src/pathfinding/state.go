@@ -0,0 +1,145 @@
+package pathfinding
+
+import (
+	"fmt"
+
+	"github.com/wthys/advent-of-code-2023/grid"
+	"github.com/wthys/advent-of-code-2023/location"
+)
+
+type (
+	// `State` is a search node that remembers where it is, which way it is
+	// heading and how many steps it has taken in that direction. A zero `Dir`
+	// means no heading has been chosen yet.
+	State struct {
+		Pos location.Location
+		Dir location.Location
+		Run int
+	}
+
+	// `MoveRules` constrain how a `State` can move. A `State` has to go
+	// straight for at least `MinRun` steps before it can turn or stop, and can
+	// go straight for at most `MaxRun` steps (0 means no limit). Turning around
+	// is only allowed with `Reverse`.
+	MoveRules struct {
+		MinRun  int
+		MaxRun  int
+		Reverse bool
+	}
+
+	// `CostFunc` returns the cost of entering `loc` holding `value`, or false
+	// when `loc` cannot be entered.
+	CostFunc[T any] func(loc location.Location, value T) (int, bool)
+)
+
+var (
+	orthogonal = []location.Location{
+		location.New(0, -1), location.New(1, 0), location.New(0, 1), location.New(-1, 0),
+	}
+)
+
+// `NewState` creates a `State` at `pos` heading in `dir` without having moved.
+func NewState(pos, dir location.Location) State {
+	return State{pos, dir, 0}
+}
+
+func (s State) String() string {
+	return fmt.Sprintf("[%v %v x%v]", s.Pos, s.Dir, s.Run)
+}
+
+// `Left` turns the heading 90 degrees counterclockwise, with y pointing down.
+func (s State) Left() location.Location {
+	return location.New(s.Dir.Y, -s.Dir.X)
+}
+
+// `Right` turns the heading 90 degrees clockwise, with y pointing down.
+func (s State) Right() location.Location {
+	return location.New(-s.Dir.Y, s.Dir.X)
+}
+
+// `Step` moves one step in `dir`, continuing the run when `dir` is the current
+// heading and starting a new one otherwise.
+func (s State) Step(dir location.Location) State {
+	if dir == s.Dir {
+		return State{s.Pos.Add(dir), dir, s.Run + 1}
+	}
+	return State{s.Pos.Add(dir), dir, 1}
+}
+
+// `Directions` lists the headings a `State` may take next according to
+// `rules`.
+func (rules MoveRules) Directions(s State) []location.Location {
+	if s.Dir == location.New(0, 0) {
+		return orthogonal
+	}
+
+	dirs := []location.Location{}
+	if rules.MaxRun <= 0 || s.Run < rules.MaxRun {
+		dirs = append(dirs, s.Dir)
+	}
+	if s.Run < rules.MinRun {
+		return dirs
+	}
+
+	dirs = append(dirs, s.Left(), s.Right())
+	if rules.Reverse {
+		dirs = append(dirs, s.Dir.Scale(-1))
+	}
+	return dirs
+}
+
+// `CanStop` reports whether a `State` has moved far enough in a straight line
+// to end its journey.
+func (rules MoveRules) CanStop(s State) bool {
+	return s.Run >= rules.MinRun
+}
+
+// `ReachedGoal` creates an `ExitFunc` that accepts a `State` at `target` that
+// is allowed to stop there.
+func ReachedGoal(target location.Location, rules MoveRules) ExitFunc[State] {
+	return func(s State) bool {
+		return s.Pos == target && rules.CanStop(s)
+	}
+}
+
+// `GridCost` creates a `CostFunc` that uses the values in the grid as cost,
+// e.g. the heat loss of a city block.
+func GridCost() CostFunc[int] {
+	return func(_ location.Location, value int) (int, bool) {
+		return value, true
+	}
+}
+
+// `GridStateNeejbers` builds a `WeightedNeejberFunc` that moves a `State`
+// around the bounds of `g` according to `rules`. The cost of every step is
+// determined by `cost`, which can also block locations.
+func GridStateNeejbers[T any](g *grid.Grid[T], rules MoveRules, cost CostFunc[T]) WeightedNeejberFunc[State] {
+	bounds, err := g.Bounds()
+	if err != nil {
+		return func(_ State) []Edge[State] {
+			return []Edge[State]{}
+		}
+	}
+
+	return func(s State) []Edge[State] {
+		edges := []Edge[State]{}
+		for _, dir := range rules.Directions(s) {
+			next := s.Step(dir)
+			if !bounds.Has(next.Pos) {
+				continue
+			}
+
+			value, err := g.Get(next.Pos)
+			if err != nil {
+				continue
+			}
+
+			c, ok := cost(next.Pos, value)
+			if !ok {
+				continue
+			}
+			edges = append(edges, Edge[State]{next, c})
+		}
+		return edges
+	}
+}
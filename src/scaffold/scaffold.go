@@ -0,0 +1,326 @@
+package scaffold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/wthys/advent-of-code-2023/solver"
+)
+
+const (
+	// `Module` is the import path of the package holding the solutions.
+	Module = "github.com/wthys/advent-of-code-2023/solutions"
+)
+
+var (
+	// `ErrNotStub` is returned when a day already has a solution.
+	ErrNotStub = errors.New("day already has a solution")
+
+	reDayDir = regexp.MustCompile(`^day([0-9]+)$`)
+
+	solutionTemplate = template.Must(template.New("solution").Parse(`package day{{.Day}}
+
+import (
+    "github.com/wthys/advent-of-code-2023/solver"
+)
+
+
+type solution struct {}
+
+func init() {
+    solver.Register(solution{})
+}
+
+func (s solution) Day() string {
+    return "{{.Day}}"
+}
+
+func (s solution) Part1(input []string) (string, error) {
+    return solver.NotImplemented()
+}
+
+func (s solution) Part2(input []string) (string, error) {
+    return solver.NotImplemented()
+}
+`))
+
+	testTemplate = template.Must(template.New("test").Funcs(template.FuncMap{"literal": literal}).Parse(`package day{{.Day}}
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/wthys/advent-of-code-2023/solver"
+)
+
+type (
+	TestCase[I any, E any] struct {
+		input    I
+		expected E
+	}
+)
+
+{{if .Example}}const example = {{literal .Example}}
+{{else}}// TODO: paste the example of the puzzle
+const example = ""
+{{end}}
+func TestExample(t *testing.T) {
+	input := strings.Split(strings.TrimRight(example, "\n"), "\n")
+
+	testcases := []TestCase[func([]string) (string, error), string]{
+		{solution{}.Part1, {{printf "%q" .Expected.Part1}}},
+		{solution{}.Part2, {{printf "%q" .Expected.Part2}}},
+	}
+
+	for idx, tc := range testcases {
+		if tc.expected == "" {
+			continue
+		}
+
+		actual, err := tc.input(input)
+		if errors.Is(err, solver.ErrNotImplemented) {
+			t.Skipf("Part%v is not implemented yet", idx+1)
+		}
+		if err != nil || actual != tc.expected {
+			t.Fatalf("Part%v(example) = %q, %v, want %q", idx+1, actual, err, tc.expected)
+		}
+	}
+}
+`))
+
+	registerTemplate = template.Must(template.New("register").Parse(`package solutions
+
+import (
+{{range .}}    _ "{{$.Module}}/day{{.}}"
+{{end}})
+`))
+)
+
+type (
+	// `Day` holds what goes into the files of a new day.
+	Day struct {
+		Day      string
+		Example  string
+		Expected solver.Answers
+	}
+
+	registration []string
+)
+
+func (r registration) Module() string {
+	return Module
+}
+
+// `New` creates the package of a day in `solutionsDir` with a stub solution
+// and a test running the example from `examplesDir`, if there is one, against
+// its expected answers. An existing test file is left alone. Returns
+// `ErrNotStub` when the day already has a solution.
+func New(solutionsDir, examplesDir, day string) error {
+	n, err := strconv.Atoi(day)
+	if err != nil || n < 1 || n > 25 {
+		return fmt.Errorf("invalid day %q, expected 1 to 25", day)
+	}
+	// "03" and "+3" are day 3 as well
+	day = strconv.Itoa(n)
+
+	dir := filepath.Join(solutionsDir, "day"+day)
+	solution := filepath.Join(dir, "solution.go")
+
+	stub, err := IsStub(solution)
+	if err != nil {
+		return err
+	}
+	if !stub {
+		return fmt.Errorf("[%s] %w in %s", day, ErrNotStub, solution)
+	}
+
+	data := Day{Day: day}
+	if examplesDir != "" {
+		if data.Example, data.Expected, err = readExample(examplesDir, day); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	if err := write(solution, solutionTemplate, data, false); err != nil {
+		return err
+	}
+
+	test := filepath.Join(dir, "solution_test.go")
+	if _, err := os.Stat(test); errors.Is(err, fs.ErrNotExist) {
+		if err := write(test, testTemplate, data, true); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	return Register(solutionsDir)
+}
+
+// `IsStub` reports whether the solution in `path` is missing or still the
+// generated stub, i.e. it declares nothing but `Day` and both parts return
+// `solver.NotImplemented()`.
+func IsStub(path string) (bool, error) {
+	src, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), path, src, 0)
+	if err != nil {
+		return false, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok == token.IMPORT {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); !ok || ts.Name.Name != "solution" {
+					return false, nil
+				}
+			}
+		case *ast.FuncDecl:
+			switch decl.Name.Name {
+			case "init", "Day":
+			case "Part1", "Part2":
+				if !returnsNotImplemented(decl) {
+					return false, nil
+				}
+			default:
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+func returnsNotImplemented(fn *ast.FuncDecl) bool {
+	if fn.Body == nil || len(fn.Body.List) != 1 {
+		return false
+	}
+
+	ret, ok := fn.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return false
+	}
+
+	call, ok := ret.Results[0].(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "solver" && sel.Sel.Name == "NotImplemented"
+}
+
+// `Register` regenerates `register.go` in `solutionsDir`, importing every
+// `dayN` directory that holds Go files, in order of day.
+func Register(solutionsDir string) error {
+	entries, err := os.ReadDir(solutionsDir)
+	if err != nil {
+		return err
+	}
+
+	days := registration{}
+	for _, entry := range entries {
+		match := reDayDir.FindStringSubmatch(entry.Name())
+		if !entry.IsDir() || match == nil {
+			continue
+		}
+
+		sources, err := filepath.Glob(filepath.Join(solutionsDir, entry.Name(), "*.go"))
+		if err != nil {
+			return err
+		}
+		if len(sources) > 0 {
+			days = append(days, match[1])
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		a, _ := strconv.Atoi(days[i])
+		b, _ := strconv.Atoi(days[j])
+		return a < b
+	})
+
+	return write(filepath.Join(solutionsDir, "register.go"), registerTemplate, days, false)
+}
+
+func readExample(examplesDir, day string) (string, solver.Answers, error) {
+	path := filepath.Join(examplesDir, fmt.Sprintf("day%s.txt", day))
+
+	example, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", solver.Answers{}, nil
+	}
+	if err != nil {
+		return "", solver.Answers{}, err
+	}
+
+	examples, err := solver.Examples(examplesDir)
+	if err != nil {
+		return "", solver.Answers{}, err
+	}
+	for _, ex := range examples {
+		if ex.Path == path {
+			return string(example), ex.Expected, nil
+		}
+	}
+
+	return string(example), solver.Answers{}, nil
+}
+
+func write(path string, tmpl *template.Template, data any, gofmt bool) error {
+	buf := bytes.Buffer{}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+
+	src := buf.Bytes()
+	if gofmt {
+		var err error
+		if src, err = format.Source(src); err != nil {
+			return fmt.Errorf("format %s: %w", path, err)
+		}
+	}
+
+	return os.WriteFile(path, src, 0o644)
+}
+
+// `literal` quotes `text` as a Go raw string literal when possible.
+func literal(text string) string {
+	if strings.Contains(text, "`") || strings.Contains(text, "\r") {
+		return strconv.Quote(text)
+	}
+	return "`" + text + "`"
+}
@@ -0,0 +1,140 @@
+package scaffold
+
+import (
+	"errors"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type (
+	TestCase[I any, E any] struct {
+		input    I
+		expected E
+	}
+)
+
+func writeFile(t *testing.T, path, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestNew(t *testing.T) {
+	root := t.TempDir()
+	solutions := filepath.Join(root, "solutions")
+	examples := filepath.Join(root, "examples")
+
+	writeFile(t, filepath.Join(examples, "day3.txt"), "467..114..\n...*......\n")
+	writeFile(t, filepath.Join(examples, "day3.answers.json"), `{"part1": "4361"}`)
+
+	if err := New(solutions, examples, "3"); err != nil {
+		t.Fatalf("New(3) failed: %v", err)
+	}
+	if err := New(solutions, examples, "10"); err != nil {
+		t.Fatalf("New(10) failed: %v", err)
+	}
+
+	for _, name := range []string{"day3/solution.go", "day3/solution_test.go", "day10/solution.go", "day10/solution_test.go", "register.go"} {
+		path := filepath.Join(solutions, name)
+		if _, err := parser.ParseFile(token.NewFileSet(), path, nil, 0); err != nil {
+			t.Fatalf("%s does not parse: %v", name, err)
+		}
+	}
+
+	test := readFile(t, filepath.Join(solutions, "day3", "solution_test.go"))
+	if !strings.Contains(test, "const example = `467..114..\n...*......\n`") || !strings.Contains(test, `{solution{}.Part1, "4361"}`) {
+		t.Fatalf("day3 test does not hold the example and its answer:\n%v", test)
+	}
+
+	register := readFile(t, filepath.Join(solutions, "register.go"))
+	if !strings.Contains(register, "solutions/day3\"\n    _ \""+Module+"/day10\"\n)") {
+		t.Fatalf("register.go does not import day3 and day10 in order:\n%v", register)
+	}
+
+	stub, err := IsStub(filepath.Join(solutions, "day3", "solution.go"))
+	if err != nil || !stub {
+		t.Fatalf("IsStub(day3) = %v, %v, want true", stub, err)
+	}
+
+	// a solved day is not overwritten
+	solved := strings.Replace(readFile(t, filepath.Join(solutions, "day3", "solution.go")), "return solver.NotImplemented()", "return solver.Solved(4361)", 1)
+	writeFile(t, filepath.Join(solutions, "day3", "solution.go"), solved)
+
+	for _, day := range []string{"3", "03", "+3"} {
+		if err := New(solutions, examples, day); !errors.Is(err, ErrNotStub) {
+			t.Fatalf("New(%v) over a solution = %v, want %v", day, err, ErrNotStub)
+		}
+	}
+	if readFile(t, filepath.Join(solutions, "day3", "solution.go")) != solved {
+		t.Fatalf("New(3) changed the existing solution")
+	}
+
+	if err := New(solutions, examples, "26"); err == nil {
+		t.Fatalf("New(26) succeeded, want an error")
+	}
+}
+
+func TestIsStub(t *testing.T) {
+	testcases := []TestCase[string, bool]{
+		{"day25", true},
+		{"day9", false},
+		{"day99", true},
+	}
+
+	for _, tc := range testcases {
+		path := filepath.Join("..", "solutions", tc.input, "solution.go")
+		actual, err := IsStub(path)
+		if err != nil || actual != tc.expected {
+			t.Fatalf("IsStub(%v) = %v, %v, want %v", tc.input, actual, err, tc.expected)
+		}
+	}
+}
+
+func TestRegisterMatchesTree(t *testing.T) {
+	dir := t.TempDir()
+	entries, err := os.ReadDir(filepath.Join("..", "solutions"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			writeFile(t, filepath.Join(dir, entry.Name(), "solution.go"), "")
+		}
+	}
+
+	if err := Register(dir); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	expected := readFile(t, filepath.Join("..", "solutions", "register.go"))
+	if actual := readFile(t, filepath.Join(dir, "register.go")); actual != expected {
+		t.Fatalf("Register =\n%v\nwant\n%v", actual, expected)
+	}
+}
+
+func TestStubMatchesTree(t *testing.T) {
+	dir := t.TempDir()
+	if err := New(dir, "", "25"); err != nil {
+		t.Fatalf("New(25) failed: %v", err)
+	}
+
+	expected := readFile(t, filepath.Join("..", "solutions", "day25", "solution.go"))
+	if actual := readFile(t, filepath.Join(dir, "day25", "solution.go")); actual != expected {
+		t.Fatalf("New(25) =\n%v\nwant\n%v", actual, expected)
+	}
+}
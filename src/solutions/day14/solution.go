@@ -6,6 +6,7 @@ import (
 	g "github.com/wthys/advent-of-code-2023/grid"
 	l "github.com/wthys/advent-of-code-2023/location"
 	"github.com/wthys/advent-of-code-2023/solver"
+	"github.com/wthys/advent-of-code-2023/util/cycle"
 )
 
 type solution struct{}
@@ -68,31 +69,16 @@ func (s solution) Part2(input []string) (string, error) {
 		return total
 	}
 
-	limit := 1000000000
-
-	lruCache := map[string]int{}
-	lruCache[Hash(platform)] = -1
-
-	loadCache := map[int]int{}
-
-	for i := 0; i < limit; i++ {
+	spin := func(platform *g.Grid[Rock]) *g.Grid[Rock] {
 		platform = Tilt(platform, NORTH)
 		platform = Tilt(platform, WEST)
 		platform = Tilt(platform, SOUTH)
-		platform = Tilt(platform, EAST)
-
-		hash := Hash(platform)
-		lru, ok := lruCache[hash]
-		if ok {
-			period := i - lru
-			likelyIdx := (limit-i)%period + lru - 1
-			return solver.Solved(loadCache[likelyIdx])
-		}
-		lruCache[hash] = i
-		loadCache[i] = loadCalc(platform)
+		return Tilt(platform, EAST)
 	}
 
-	return solver.Error(fmt.Errorf("Could not find a period (I'm sorry)"))
+	spinCycle := cycle.Detect(platform, spin, (*g.Grid[Rock]).Hash)
+
+	return solver.Solved(cycle.ValueAt(spinCycle, 1000000000, loadCalc))
 }
 
 const (
@@ -170,26 +156,6 @@ func (rock NoRock) Pos() l.Location {
 	return rock.pos
 }
 
-func Hash(platform *g.Grid[Rock]) string {
-	bounds, _ := platform.Bounds()
-	out := ""
-	for y := bounds.Ymin; y <= bounds.Ymax; y++ {
-		for x := bounds.Xmin; x <= bounds.Xmax; x++ {
-			loc := l.New(x, y)
-			rock, err := platform.Get(loc)
-			if err != nil {
-				continue
-			}
-
-			_, round := rock.(RoundRock)
-			if round {
-				out += rock.Pos().String()
-			}
-		}
-	}
-	return out
-}
-
 func Tilt(grid *g.Grid[Rock], direction Cardinal) *g.Grid[Rock] {
 	platform := g.WithDefaultFunc[Rock](func(loc l.Location) (Rock, error) {
 		return NoRock{loc}, nil
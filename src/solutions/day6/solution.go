@@ -67,7 +67,7 @@ func (race Race) Options() interval.Interval {
 	for lohi-lolo > 1 {
 		lomid := (lolo + lohi) / 2
 		d := race.race(lomid)
-		if d < race.record {
+		if d <= race.record {
 			lolo = lomid
 		} else {
 			lohi = lomid
@@ -79,7 +79,7 @@ func (race Race) Options() interval.Interval {
 	for hihi-hilo > 1 {
 		himid := (hilo + hihi) / 2
 		d := race.race(himid)
-		if d < race.record {
+		if d <= race.record {
 			hihi = himid
 		} else {
 			hilo = himid
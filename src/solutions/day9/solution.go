@@ -2,8 +2,6 @@ package day9
 
 import (
 	"fmt"
-	"regexp"
-	"strconv"
 
 	"github.com/wthys/advent-of-code-2023/collections/set"
 	"github.com/wthys/advent-of-code-2023/solver"
@@ -109,19 +107,20 @@ func (ms Measurements) Prev() Measurement {
 }
 
 func ParseInput(input []string) (Histories, error) {
-	reNum := regexp.MustCompile(`-?[0-9]+`)
+	lines, err := solver.NewInput(input).Ints()
+	if err != nil {
+		return Histories{}, err
+	}
 
 	histories := Histories{}
 
-	for _, line := range input {
-		matches := reNum.FindAllString(line, -1)
-		if len(matches) == 0 {
+	for _, nums := range lines {
+		if len(nums) == 0 {
 			continue
 		}
 
 		measurements := Measurements{}
-		for _, m := range matches {
-			num, _ := strconv.Atoi(m)
+		for _, num := range nums {
 			measurements = append(measurements, Measurement(num))
 		}
 
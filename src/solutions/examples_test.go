@@ -0,0 +1,41 @@
+package solutions
+
+import (
+    "errors"
+    "io/fs"
+    "path/filepath"
+    "testing"
+
+    "github.com/wthys/advent-of-code-2023/solver"
+)
+
+// examplesDir is where the examples live, relative to this package. It is not
+// available when building in Docker, so the tests skip themselves there.
+var examplesDir = filepath.Join("..", "..", "examples")
+
+func TestExamples(t *testing.T) {
+    examples, err := solver.Examples(examplesDir)
+    if errors.Is(err, fs.ErrNotExist) {
+        t.Skipf("no examples in %s", examplesDir)
+    }
+    if err != nil {
+        t.Fatalf("Examples(%q) failed: %v", examplesDir, err)
+    }
+
+    for _, example := range examples {
+        example := example
+        t.Run(example.Name(), func(t *testing.T) {
+            if example.Expected == (solver.Answers{}) {
+                t.Skipf("no expected answers in %s", solver.ExpectedPath(example.Path))
+            }
+
+            mismatches, err := example.Check()
+            if err != nil {
+                t.Fatalf("%s failed: %v", example.Path, err)
+            }
+            for _, mismatch := range mismatches {
+                t.Errorf("%s %v", example.Path, mismatch)
+            }
+        })
+    }
+}
@@ -0,0 +1,256 @@
+package solver
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "io/fs"
+    "os"
+    "runtime"
+    "sort"
+    "text/tabwriter"
+    "time"
+)
+
+// BenchOptions determine how often a part is run: at least Runs times and for
+// at least MinDuration, whichever takes longer.
+type BenchOptions struct {
+    Runs int
+    MinDuration time.Duration
+}
+
+// Stats summarises the runs of a single part. A part that is not implemented
+// has no runs.
+type Stats struct {
+    Runs int `json:"runs"`
+    Min time.Duration `json:"min"`
+    Median time.Duration `json:"median"`
+    Mean time.Duration `json:"mean"`
+    P95 time.Duration `json:"p95"`
+    Allocs uint64 `json:"allocs"`
+    Bytes uint64 `json:"bytes"`
+}
+
+// BenchResult holds the stats of both parts of a day.
+type BenchResult struct {
+    Day string `json:"day"`
+    Part1 Stats `json:"part1"`
+    Part2 Stats `json:"part2"`
+}
+
+// BenchReport is the machine-readable outcome of a benchmark, to compare
+// against later.
+type BenchReport struct {
+    Year string `json:"year"`
+    Time time.Time `json:"time"`
+    Results []BenchResult `json:"results"`
+}
+
+// Comparison is the difference in median time of a part between two
+// benchmarks. Change is relative to Old, so -0.5 means twice as fast.
+type Comparison struct {
+    Day string
+    Part int
+    Old Stats
+    New Stats
+    Change float64
+}
+
+// Bench runs both parts of a solver on the same input according to opts.
+func Bench(ctx context.Context, s Solver, input []string, opts BenchOptions) (BenchResult, error) {
+    cs := WithContext(s)
+    res := BenchResult{Day: s.Day()}
+
+    var err error
+    if res.Part1, err = benchPart(ctx, cs.Part1Context, input, opts); err != nil {
+        return res, fmt.Errorf("[%s] failed to bench Part1: %w", s.Day(), err)
+    }
+    if res.Part2, err = benchPart(ctx, cs.Part2Context, input, opts); err != nil {
+        return res, fmt.Errorf("[%s] failed to bench Part2: %w", s.Day(), err)
+    }
+
+    return res, nil
+}
+
+func benchPart(ctx context.Context, part func(context.Context, []string) (string, error), input []string, opts BenchOptions) (Stats, error) {
+    durations := []time.Duration{}
+    total := time.Duration(0)
+
+    var before, after runtime.MemStats
+    runtime.GC()
+    runtime.ReadMemStats(&before)
+
+    for len(durations) == 0 || len(durations) < opts.Runs || total < opts.MinDuration {
+        start := time.Now()
+        _, err := part(ctx, input)
+        elapsed := time.Since(start)
+
+        if errors.Is(err, ErrNotImplemented) {
+            return Stats{}, nil
+        }
+        if err != nil {
+            return Stats{}, err
+        }
+
+        durations = append(durations, elapsed)
+        total += elapsed
+    }
+
+    runtime.ReadMemStats(&after)
+
+    return summarise(durations, after.Mallocs-before.Mallocs, after.TotalAlloc-before.TotalAlloc), nil
+}
+
+func summarise(durations []time.Duration, allocs, bytes uint64) Stats {
+    sorted := append([]time.Duration{}, durations...)
+    sort.Slice(sorted, func(i, j int) bool {
+        return sorted[i] < sorted[j]
+    })
+
+    runs := len(sorted)
+    total := time.Duration(0)
+    for _, d := range sorted {
+        total += d
+    }
+
+    median := sorted[runs/2]
+    if runs%2 == 0 {
+        median = (sorted[runs/2-1] + sorted[runs/2]) / 2
+    }
+
+    p95 := sorted[(runs*95+99)/100-1]
+
+    return Stats{
+        Runs: runs,
+        Min: sorted[0],
+        Median: median,
+        Mean: total / time.Duration(runs),
+        P95: p95,
+        Allocs: allocs / uint64(runs),
+        Bytes: bytes / uint64(runs),
+    }
+}
+
+// BenchAll benchmarks the given days of a year, or all its registered days
+// when there are none, reading the input of each day with load. Days without
+// input are skipped.
+func BenchAll(ctx context.Context, year string, load InputLoader, days []string, opts BenchOptions) (BenchReport, error) {
+    report := BenchReport{year, time.Now(), []BenchResult{}}
+
+    selected, err := selectSolvers(year, days)
+    if err != nil {
+        return report, err
+    }
+
+    for _, s := range selected {
+        file, err := load(s.Day())
+        if errors.Is(err, fs.ErrNotExist) {
+            continue
+        }
+        if err != nil {
+            return report, fmt.Errorf("[%s] open input: %w", s.Day(), err)
+        }
+
+        lines, err := ReadLines(file)
+        file.Close()
+        if err != nil {
+            return report, fmt.Errorf("[%s] failed to read: %w", s.Day(), err)
+        }
+
+        res, err := Bench(ctx, s, lines, opts)
+        if err != nil {
+            return report, err
+        }
+        report.Results = append(report.Results, res)
+    }
+
+    return report, nil
+}
+
+// ReadBenchReport reads a report written by WriteBenchReport.
+func ReadBenchReport(path string) (BenchReport, error) {
+    report := BenchReport{}
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return report, err
+    }
+
+    if err := json.Unmarshal(data, &report); err != nil {
+        return report, fmt.Errorf("parse %s: %w", path, err)
+    }
+
+    return report, nil
+}
+
+// WriteBenchReport stores a report as JSON.
+func WriteBenchReport(path string, report BenchReport) error {
+    data, err := json.MarshalIndent(report, "", "  ")
+    if err != nil {
+        return err
+    }
+    return writeFile(path, append(data, '\n'))
+}
+
+// Compare matches the parts in two reports and computes the change in their
+// median time. Parts that are missing or not implemented in either report
+// are left out.
+func Compare(old, new BenchReport) []Comparison {
+    previous := map[string]BenchResult{}
+    for _, res := range old.Results {
+        previous[res.Day] = res
+    }
+
+    comparisons := []Comparison{}
+    for _, res := range new.Results {
+        prev, ok := previous[res.Day]
+        if !ok {
+            continue
+        }
+
+        parts := [][2]Stats{{prev.Part1, res.Part1}, {prev.Part2, res.Part2}}
+        for idx, stats := range parts {
+            if stats[0].Runs == 0 || stats[1].Runs == 0 || stats[0].Median == 0 {
+                continue
+            }
+            change := float64(stats[1].Median - stats[0].Median) / float64(stats[0].Median)
+            comparisons = append(comparisons, Comparison{res.Day, idx + 1, stats[0], stats[1], change})
+        }
+    }
+
+    return comparisons
+}
+
+// WriteBenchTable writes the stats of every part as an aligned table.
+func WriteBenchTable(w io.Writer, report BenchReport) error {
+    tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+    fmt.Fprintln(tw, "DAY\tPART\tRUNS\tMIN\tMEDIAN\tMEAN\tP95\tALLOCS/OP\tBYTES/OP")
+
+    for _, res := range report.Results {
+        for idx, stats := range []Stats{res.Part1, res.Part2} {
+            if stats.Runs == 0 {
+                fmt.Fprintf(tw, "%v\t%v\t-\t-\t-\t-\t-\t-\t-\n", res.Day, idx + 1)
+                continue
+            }
+            fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", res.Day, idx + 1, stats.Runs, stats.Min, stats.Median, stats.Mean, stats.P95, stats.Allocs, stats.Bytes)
+        }
+    }
+
+    return tw.Flush()
+}
+
+// WriteComparisonTable writes the comparisons as an aligned table.
+func WriteComparisonTable(w io.Writer, comparisons []Comparison) error {
+    tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+    fmt.Fprintln(tw, "DAY\tPART\tOLD\tNEW\tCHANGE\tOLD ALLOCS/OP\tNEW ALLOCS/OP")
+
+    for _, c := range comparisons {
+        fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%+.1f%%\t%v\t%v\n", c.Day, c.Part, c.Old.Median, c.New.Median, c.Change * 100, c.Old.Allocs, c.New.Allocs)
+    }
+
+    return tw.Flush()
+}
@@ -0,0 +1,149 @@
+package solver
+
+import (
+    "context"
+    "fmt"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+type unimplementedSolver struct{}
+
+func (s unimplementedSolver) Part1(input []string) (string, error) {
+    return NotImplemented()
+}
+
+func (s unimplementedSolver) Part2(input []string) (string, error) {
+    return NotImplemented()
+}
+
+func (s unimplementedSolver) Day() string {
+    return "0"
+}
+
+func TestSummarise(t *testing.T) {
+    durations := []time.Duration{}
+    for i := 20; i > 0; i-- {
+        durations = append(durations, time.Duration(i) * time.Millisecond)
+    }
+
+    stats := summarise(durations, 40, 400)
+    expected := Stats{
+        Runs: 20,
+        Min: time.Millisecond,
+        Median: 10500 * time.Microsecond,
+        Mean: 10500 * time.Microsecond,
+        P95: 19 * time.Millisecond,
+        Allocs: 2,
+        Bytes: 20,
+    }
+    if stats != expected {
+        t.Fatalf("summarise = %+v, want %+v", stats, expected)
+    }
+}
+
+func TestBench(t *testing.T) {
+    s := contextSolver{countingSolver{}}
+    ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+    defer cancel()
+
+    if _, err := Bench(ctx, s, []string{"a"}, BenchOptions{Runs: 3}); err == nil {
+        t.Fatalf("Bench of a part that never finishes succeeded")
+    }
+
+    res, err := Bench(context.Background(), unimplementedSolver{}, nil, BenchOptions{Runs: 3})
+    if err != nil || res.Part1.Runs != 0 || res.Part2.Runs != 0 {
+        t.Fatalf("Bench of an unimplemented solver = %+v, %v, want no runs", res, err)
+    }
+
+    legacy := blockingSolver{make(chan struct{})}
+    close(legacy.release)
+    res, err = Bench(context.Background(), legacy, nil, BenchOptions{Runs: 3, MinDuration: time.Millisecond})
+    if err != nil || res.Part1.Runs < 3 || res.Part2.Runs < 3 {
+        t.Fatalf("Bench = %+v, %v, want at least 3 runs per part", res, err)
+    }
+}
+
+func TestCompare(t *testing.T) {
+    old := BenchReport{Results: []BenchResult{
+        {"1", Stats{Runs: 1, Median: 10}, Stats{Runs: 1, Median: 40}},
+        {"2", Stats{Runs: 1, Median: 10}, Stats{}},
+    }}
+    new := BenchReport{Results: []BenchResult{
+        {"1", Stats{Runs: 1, Median: 5}, Stats{Runs: 1, Median: 50}},
+        {"2", Stats{Runs: 1, Median: 10}, Stats{Runs: 1, Median: 10}},
+        {"3", Stats{Runs: 1, Median: 10}, Stats{Runs: 1, Median: 10}},
+    }}
+
+    comparisons := Compare(old, new)
+    expected := []struct {
+        day string
+        part int
+        change float64
+    }{
+        {"1", 1, -0.5},
+        {"1", 2, 0.25},
+        {"2", 1, 0},
+    }
+    if len(comparisons) != len(expected) {
+        t.Fatalf("Compare = %+v, want %d comparisons", comparisons, len(expected))
+    }
+    for idx, c := range comparisons {
+        e := expected[idx]
+        if c.Day != e.day || c.Part != e.part || c.Change != e.change {
+            t.Fatalf("Compare[%d] = %v/%v %v, want %v/%v %v", idx, c.Day, c.Part, c.Change, e.day, e.part, e.change)
+        }
+    }
+
+    path := filepath.Join(t.TempDir(), "bench.json")
+    if err := WriteBenchReport(path, new); err != nil {
+        t.Fatalf("WriteBenchReport failed: %v", err)
+    }
+    read, err := ReadBenchReport(path)
+    if err != nil || len(read.Results) != 3 || read.Results[0] != new.Results[0] {
+        t.Fatalf("ReadBenchReport = %+v, %v, want %+v", read, err, new)
+    }
+}
+
+func TestBenchAll(t *testing.T) {
+    registerLineSolvers(t)
+
+    inputs := stubInputs(map[string]string{"1": "a\nb\n", "3": "c\n"}, "")
+    opts := BenchOptions{Runs: 2}
+    ctx := context.Background()
+
+    testcases := []TestCase[[]string, []string]{
+        {nil, []string{"1", "3"}},
+        {[]string{"3", "1"}, []string{"3", "1"}},
+        {[]string{"2", "10"}, []string{}},
+    }
+
+    for _, tc := range testcases {
+        report, err := BenchAll(ctx, "1915", inputs, tc.input, opts)
+        if err != nil || report.Year != "1915" {
+            t.Fatalf("BenchAll(%v) = %v for %v, want %v", tc.input, err, report.Year, "1915")
+        }
+
+        days := []string{}
+        for _, res := range report.Results {
+            days = append(days, res.Day)
+            if res.Part1.Runs < opts.Runs {
+                t.Fatalf("BenchAll(%v) ran part 1 of day %v %v times, want at least %v", tc.input, res.Day, res.Part1.Runs, opts.Runs)
+            }
+            if implemented := res.Day != "3"; implemented != (res.Part2.Runs > 0) {
+                t.Fatalf("BenchAll(%v) ran part 2 of day %v %v times", tc.input, res.Day, res.Part2.Runs)
+            }
+        }
+        if fmt.Sprint(days) != fmt.Sprint(tc.expected) {
+            t.Fatalf("BenchAll(%v) benched days %v, want %v", tc.input, days, tc.expected)
+        }
+    }
+
+    if _, err := BenchAll(ctx, "1915", inputs, []string{"7"}, opts); err == nil {
+        t.Fatalf("BenchAll(7) succeeded, want an error for an unknown day")
+    }
+    if _, err := BenchAll(ctx, "1915", stubInputs(map[string]string{}, "1"), nil, opts); err == nil {
+        t.Fatalf("BenchAll with a broken input succeeded, want an error")
+    }
+}
@@ -0,0 +1,96 @@
+package solver
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "io"
+    "io/fs"
+    "os"
+    "path/filepath"
+)
+
+const (
+    // DefaultYear is the event solutions belong to, unless they declare
+    // otherwise as a YearSolver.
+    DefaultYear = "2023"
+)
+
+// Cache stores fetched puzzle data on disk, in one directory per year and day.
+type Cache struct {
+    Dir string
+}
+
+// DefaultCacheDir returns the per-user cache directory, falling back to a
+// directory in the working directory when there is none.
+func DefaultCacheDir() string {
+    dir, err := os.UserCacheDir()
+    if err != nil {
+        return ".aoc-cache"
+    }
+    return filepath.Join(dir, "aoc")
+}
+
+// DayDir returns the directory holding all cached data for a year and day.
+func (c Cache) DayDir(year, day string) string {
+    return filepath.Join(c.Dir, year, fmt.Sprintf("day%s", day))
+}
+
+// InputPath returns where the input for a year and day is cached.
+func (c Cache) InputPath(year, day string) string {
+    return filepath.Join(c.DayDir(year, day), "input.txt")
+}
+
+// ReadInput returns the cached input for a year and day. The error wraps
+// fs.ErrNotExist when the input was never cached.
+func (c Cache) ReadInput(year, day string) ([]byte, error) {
+    return os.ReadFile(c.InputPath(year, day))
+}
+
+// WriteInput stores the input for a year and day.
+func (c Cache) WriteInput(year, day string, input []byte) error {
+    return writeFile(c.InputPath(year, day), input)
+}
+
+// Inputs creates an InputLoader reading cached inputs for a year.
+func (c Cache) Inputs(year string) InputLoader {
+    return func(day string) (io.ReadCloser, error) {
+        return os.Open(c.InputPath(year, day))
+    }
+}
+
+// FetchInput returns the cached input for a year and day, retrieving and caching it
+// with GetInput when it is missing or refresh is set.
+func (c Cache) FetchInput(ctx context.Context, year, day string, session string, refresh bool) ([]byte, error) {
+    if !refresh {
+        input, err := c.ReadInput(year, day)
+        if err == nil {
+            return input, nil
+        }
+        if !errors.Is(err, fs.ErrNotExist) {
+            return nil, fmt.Errorf("read cached input: %w", err)
+        }
+    }
+
+    if session == "" {
+        return nil, fmt.Errorf("[%s] input not cached and no session token provided: %w", day, ErrUnauthorized)
+    }
+
+    input, err := GetInput(ctx, year, day, session)
+    if err != nil {
+        return nil, err
+    }
+
+    if err := c.WriteInput(year, day, input); err != nil {
+        return nil, fmt.Errorf("cache input: %w", err)
+    }
+
+    return input, nil
+}
+
+func writeFile(path string, data []byte) error {
+    if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+        return err
+    }
+    return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,77 @@
+package solver
+
+import (
+    "context"
+    "errors"
+    "io"
+    "net/http"
+    "strings"
+    "testing"
+)
+
+type fakeClient struct {
+    status int
+    body string
+    requests []*http.Request
+}
+
+func (f *fakeClient) Do(req *http.Request) (*http.Response, error) {
+    f.requests = append(f.requests, req)
+    return &http.Response{
+        StatusCode: f.status,
+        Status: http.StatusText(f.status),
+        Body: io.NopCloser(strings.NewReader(f.body)),
+        Header: http.Header{},
+        Request: req,
+    }, nil
+}
+
+func withClient(t *testing.T, client ClientDo) {
+    previous := Client
+    Client = client
+    t.Cleanup(func() {
+        Client = previous
+    })
+}
+
+func TestFetchInputCaches(t *testing.T) {
+    fake := &fakeClient{status: http.StatusOK, body: "1 2 3\n"}
+    withClient(t, fake)
+
+    cache := Cache{Dir: t.TempDir()}
+    ctx := context.Background()
+
+    for i := 0; i < 3; i++ {
+        input, err := cache.FetchInput(ctx, DefaultYear, "5", "secret", false)
+        if err != nil || string(input) != fake.body {
+            t.Fatalf("FetchInput(5) = %q, %v, want %q, %v", input, err, fake.body, nil)
+        }
+    }
+
+    if len(fake.requests) != 1 {
+        t.Fatalf("FetchInput(5) sent %v requests, want %v", len(fake.requests), 1)
+    }
+
+    fake.body = "4 5 6\n"
+    input, err := cache.FetchInput(ctx, DefaultYear, "5", "secret", true)
+    if err != nil || string(input) != fake.body || len(fake.requests) != 2 {
+        t.Fatalf("FetchInput(5, refresh) = %q, %v after %v requests, want %q after %v", input, err, len(fake.requests), fake.body, 2)
+    }
+
+    cached, err := cache.ReadInput(DefaultYear, "5")
+    if err != nil || string(cached) != fake.body {
+        t.Fatalf("ReadInput(5) = %q, %v, want the refreshed %q", cached, err, fake.body)
+    }
+}
+
+func TestFetchInputWithoutSession(t *testing.T) {
+    fake := &fakeClient{status: http.StatusOK, body: "1 2 3\n"}
+    withClient(t, fake)
+
+    cache := Cache{Dir: t.TempDir()}
+
+    _, err := cache.FetchInput(context.Background(), DefaultYear, "5", "", false)
+    if !errors.Is(err, ErrUnauthorized) || len(fake.requests) != 0 {
+        t.Fatalf("FetchInput(5) without session = %v after %v requests, want %v without requests", err, len(fake.requests), ErrUnauthorized)
+    }
+}
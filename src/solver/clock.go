@@ -0,0 +1,34 @@
+package solver
+
+import (
+    "context"
+    "time"
+)
+
+// Clock tells the time and waits. Tests replace SystemClock to control both.
+type Clock interface {
+    Now() time.Time
+    // Sleep waits for d, or returns ctx.Err() when the context is done first.
+    Sleep(ctx context.Context, d time.Duration) error
+}
+
+type systemClock struct{}
+
+// SystemClock is the Clock used to schedule requests to AoC.
+var SystemClock Clock = systemClock{}
+
+func (systemClock) Now() time.Time {
+    return time.Now()
+}
+
+func (systemClock) Sleep(ctx context.Context, d time.Duration) error {
+    timer := time.NewTimer(d)
+    defer timer.Stop()
+
+    select {
+        case <-timer.C:
+            return nil
+        case <-ctx.Done():
+            return ctx.Err()
+    }
+}
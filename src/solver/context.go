@@ -0,0 +1,124 @@
+package solver
+
+import (
+    "context"
+)
+
+// ContextSolver is a Solver that can be cancelled. Its parts should return
+// ctx.Err() once the context is done, e.g. by checking it in their main loop.
+type ContextSolver interface {
+    Part1Context(ctx context.Context, input []string) (string, error)
+    Part2Context(ctx context.Context, input []string) (string, error)
+    Day() string
+}
+
+type contextKey int
+
+const (
+    elapsedKey contextKey = iota
+    parallelKey
+)
+
+// WithElapsed returns a context asking to measure how long each part takes.
+func WithElapsed(ctx context.Context) context.Context {
+    return context.WithValue(ctx, elapsedKey, true)
+}
+
+// ElapsedFrom reports whether the context asks to measure how long each part
+// takes.
+func ElapsedFrom(ctx context.Context) bool {
+    elapsed, ok := ctx.Value(elapsedKey).(bool)
+    return ok && elapsed
+}
+
+// WithParallel returns a context asking to solve both parts at the same time.
+func WithParallel(ctx context.Context) context.Context {
+    return context.WithValue(ctx, parallelKey, true)
+}
+
+// ParallelFrom reports whether the context asks to solve both parts at the
+// same time.
+func ParallelFrom(ctx context.Context) bool {
+    parallel, ok := ctx.Value(parallelKey).(bool)
+    return ok && parallel
+}
+
+// RegisterContext registers a ContextSolver. It is also available as a plain
+// Solver, which solves without a deadline.
+func RegisterContext(s ContextSolver) {
+    if s == nil {
+        panic("puzzle: RegisterContext solver is nil")
+    }
+
+    Register(contextSolver{s})
+}
+
+// WithContext returns s as a ContextSolver. Solvers that do not support
+// cancellation are run in the background, and abandoned when the context is
+// done before they finish.
+func WithContext(s Solver) ContextSolver {
+    if cs, ok := s.(ContextSolver); ok {
+        return cs
+    }
+    return legacySolver{s}
+}
+
+type contextSolver struct {
+    ContextSolver
+}
+
+// Year passes on the year of the wrapped solver, so it is registered for the
+// right event.
+func (s contextSolver) Year() string {
+    if ys, ok := s.ContextSolver.(interface{ Year() string }); ok {
+        return ys.Year()
+    }
+    return DefaultYear
+}
+
+func (s contextSolver) Part1(input []string) (string, error) {
+    return s.Part1Context(context.Background(), input)
+}
+
+func (s contextSolver) Part2(input []string) (string, error) {
+    return s.Part2Context(context.Background(), input)
+}
+
+type legacySolver struct {
+    Solver
+}
+
+func (s legacySolver) Part1Context(ctx context.Context, input []string) (string, error) {
+    return solveInBackground(ctx, s.Part1, input)
+}
+
+func (s legacySolver) Part2Context(ctx context.Context, input []string) (string, error) {
+    return solveInBackground(ctx, s.Part2, input)
+}
+
+type answer struct {
+    value string
+    err error
+}
+
+func solveInBackground(ctx context.Context, part func([]string) (string, error), input []string) (string, error) {
+    if err := ctx.Err(); err != nil {
+        return Unsolved, err
+    }
+    if ctx.Done() == nil {
+        return part(input)
+    }
+
+    done := make(chan answer, 1)
+    go func() {
+        value, err := part(input)
+        done <- answer{value, err}
+    }()
+
+    select {
+        case ans := <-done:
+            return ans.value, ans.err
+        case <-ctx.Done():
+            return Unsolved, ctx.Err()
+    }
+}
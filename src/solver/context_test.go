@@ -0,0 +1,191 @@
+package solver
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+)
+
+type blockingSolver struct {
+    release chan struct{}
+}
+
+func (s blockingSolver) Part1(input []string) (string, error) {
+    return "fast", nil
+}
+
+func (s blockingSolver) Part2(input []string) (string, error) {
+    <-s.release
+    return "slow", nil
+}
+
+func (s blockingSolver) Day() string {
+    return "0"
+}
+
+type countingSolver struct{}
+
+func (s countingSolver) Part1Context(ctx context.Context, input []string) (string, error) {
+    return Solved(len(input))
+}
+
+func (s countingSolver) Part2Context(ctx context.Context, input []string) (string, error) {
+    for {
+        select {
+            case <-ctx.Done():
+                return Unsolved, ctx.Err()
+            case <-time.After(time.Millisecond):
+        }
+    }
+}
+
+func (s countingSolver) Day() string {
+    return "0"
+}
+
+// rendezvousSolver only finishes its parts when they run at the same time.
+// Part 2 takes slow longer after that, and either part can fail.
+type rendezvousSolver struct {
+    started1 chan struct{}
+    started2 chan struct{}
+    slow time.Duration
+    err1 error
+    err2 error
+}
+
+func newRendezvousSolver(err1, err2 error) rendezvousSolver {
+    return rendezvousSolver{make(chan struct{}), make(chan struct{}), 50 * time.Millisecond, err1, err2}
+}
+
+func (s rendezvousSolver) Part1Context(ctx context.Context, input []string) (string, error) {
+    close(s.started1)
+    select {
+        case <-s.started2:
+        case <-ctx.Done():
+            return Unsolved, ctx.Err()
+    }
+    if s.err1 != nil {
+        return Error(s.err1)
+    }
+    return "one", nil
+}
+
+func (s rendezvousSolver) Part2Context(ctx context.Context, input []string) (string, error) {
+    close(s.started2)
+    select {
+        case <-s.started1:
+        case <-ctx.Done():
+            return Unsolved, ctx.Err()
+    }
+    time.Sleep(s.slow)
+    if s.err2 != nil {
+        return Error(s.err2)
+    }
+    return "two", nil
+}
+
+func (s rendezvousSolver) Day() string {
+    return "0"
+}
+
+func TestAddAnswersParallel(t *testing.T) {
+    err1, err2 := errors.New("part 1 failed"), errors.New("part 2 failed")
+
+    testcases := []struct {
+        name string
+        solver rendezvousSolver
+        expected Result
+        err error
+    }{
+        {"solved", newRendezvousSolver(nil, nil), Result{Part1: "one", Part2: "two", Status: StatusSolved}, nil},
+        {"part 1 fails", newRendezvousSolver(err1, nil), Result{Part1: "", Part2: "", Status: StatusError}, err1},
+        {"part 2 fails", newRendezvousSolver(nil, err2), Result{Part1: "one", Part2: "", Status: StatusError}, err2},
+    }
+
+    for _, tc := range testcases {
+        // solving the parts one after the other runs into the timeout
+        ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+        ctx = WithParallel(WithElapsed(ctx))
+
+        res := Result{}
+        err := res.AddAnswers(contextSolver{tc.solver}, nil, ctx)
+        cancel()
+
+        if !errors.Is(err, tc.err) || (tc.err == nil) != (err == nil) {
+            t.Fatalf("[%s] AddAnswers = %v, want %v", tc.name, err, tc.err)
+        }
+        if res.Part1 != tc.expected.Part1 || res.Part2 != tc.expected.Part2 || res.Status != tc.expected.Status {
+            t.Fatalf("[%s] AddAnswers = %v (%v), want %v (%v)", tc.name, res, res.Status, tc.expected, tc.expected.Status)
+        }
+        if tc.err != nil {
+            continue
+        }
+        if len(res.Elapsed) != 2 || res.Elapsed[1] < tc.solver.slow || res.Elapsed[0] >= tc.solver.slow {
+            t.Fatalf("[%s] AddAnswers took %v, want only part 2 to take at least %v", tc.name, res.Elapsed, tc.solver.slow)
+        }
+    }
+}
+
+func TestElapsedFrom(t *testing.T) {
+    ctx := context.Background()
+    if ElapsedFrom(ctx) {
+        t.Fatalf("ElapsedFrom(Background) = true, want false")
+    }
+    if ElapsedFrom(context.WithValue(ctx, "elapsed", true)) {
+        t.Fatalf("ElapsedFrom with a string key = true, want false")
+    }
+    if !ElapsedFrom(WithElapsed(ctx)) {
+        t.Fatalf("ElapsedFrom(WithElapsed) = false, want true")
+    }
+}
+
+func TestAddAnswersTimeout(t *testing.T) {
+    legacy := blockingSolver{make(chan struct{})}
+    // lets the abandoned Part2 finish once the test is done
+    t.Cleanup(func() {
+        close(legacy.release)
+    })
+
+    testcases := []struct {
+        name string
+        solver Solver
+    }{
+        {"legacy", legacy},
+        {"context", contextSolver{countingSolver{}}},
+    }
+
+    for _, tc := range testcases {
+        ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+        res := Result{}
+        err := res.AddAnswers(tc.solver, []string{"a", "b"}, ctx)
+        cancel()
+
+        if !errors.Is(err, context.DeadlineExceeded) || res.Status != StatusTimeout {
+            t.Fatalf("[%s] AddAnswers = %v (%v), want %v", tc.name, err, res.Status, StatusTimeout)
+        }
+        if res.Part1 == Unsolved || res.Part1 == "" {
+            t.Fatalf("[%s] Part1 = %q, want the answer from before the timeout", tc.name, res.Part1)
+        }
+    }
+}
+
+func TestWithContext(t *testing.T) {
+    legacy := blockingSolver{make(chan struct{})}
+    close(legacy.release)
+
+    answer, err := WithContext(legacy).Part2Context(context.Background(), nil)
+    if err != nil || answer != "slow" {
+        t.Fatalf("Part2Context = %q, %v, want %q", answer, err, "slow")
+    }
+
+    wrapped := contextSolver{countingSolver{}}
+    if _, ok := WithContext(wrapped).(contextSolver); !ok {
+        t.Fatalf("WithContext wrapped a ContextSolver again")
+    }
+
+    answer, err = wrapped.Part1([]string{"a", "b", "c"})
+    if err != nil || answer != "3" {
+        t.Fatalf("Part1 = %q, %v, want %q", answer, err, "3")
+    }
+}
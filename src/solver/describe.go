@@ -0,0 +1,285 @@
+package solver
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "html"
+    "io/fs"
+    "net/http"
+    "os"
+    "path/filepath"
+    "regexp"
+    "strings"
+)
+
+var (
+    reDayArticle = regexp.MustCompile(`(?s)<article class="day-desc"[^>]*>(.*?)</article>`)
+    reExampleBlock = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+    reToken = regexp.MustCompile(`(?s)<(/?)([a-zA-Z0-9]+)([^>]*)>|[^<]+`)
+    reHref = regexp.MustCompile(`href="([^"]*)"`)
+)
+
+// Puzzle is the description of a puzzle, with one article per unlocked part.
+type Puzzle struct {
+    Articles []string
+}
+
+// ParsePuzzle extracts the description of a puzzle from its HTML page.
+func ParsePuzzle(page string) Puzzle {
+    puzzle := Puzzle{[]string{}}
+    for _, match := range reDayArticle.FindAllStringSubmatch(page, -1) {
+        puzzle.Articles = append(puzzle.Articles, match[1])
+    }
+    return puzzle
+}
+
+// Example returns the first example block of the description, which usually
+// is the example input of part 1.
+func (p Puzzle) Example() (string, bool) {
+    for _, article := range p.Articles {
+        if match := reExampleBlock.FindStringSubmatch(article); match != nil {
+            return html.UnescapeString(reTag.ReplaceAllString(match[1], "")), true
+        }
+    }
+    return "", false
+}
+
+// Render converts the description to readable text, or Markdown with
+// FormatMarkdown.
+func (p Puzzle) Render(format Format) (string, error) {
+    if format != FormatText && format != FormatMarkdown {
+        return "", fmt.Errorf("cannot describe a puzzle as %q, expected %v or %v", format, FormatText, FormatMarkdown)
+    }
+
+    articles := []string{}
+    for _, article := range p.Articles {
+        articles = append(articles, renderBlocks(parseBlocks(article, format), format))
+    }
+    return strings.Join(articles, "\n"), nil
+}
+
+// PuzzlePath returns where the puzzle page of a year and day is cached.
+func (c Cache) PuzzlePath(year, day string) string {
+    return filepath.Join(c.DayDir(year, day), "puzzle.html")
+}
+
+// FetchPuzzle returns the cached puzzle page of a year and day, retrieving and
+// caching it when it is missing or refresh is set. When only part 1 is cached
+// and there is a session, the page is retrieved again in case part 2 has been
+// unlocked since.
+func (c Cache) FetchPuzzle(ctx context.Context, year, day string, session string, refresh bool) (Puzzle, error) {
+    if !refresh {
+        page, err := os.ReadFile(c.PuzzlePath(year, day))
+        if err != nil && !errors.Is(err, fs.ErrNotExist) {
+            return Puzzle{}, fmt.Errorf("read cached puzzle: %w", err)
+        }
+
+        puzzle := ParsePuzzle(string(page))
+        if err == nil && (len(puzzle.Articles) > 1 || session == "") {
+            return puzzle, nil
+        }
+    }
+
+    page, err := GetPuzzle(ctx, year, day, session)
+    if err != nil {
+        return Puzzle{}, err
+    }
+
+    puzzle := ParsePuzzle(string(page))
+    if len(puzzle.Articles) == 0 {
+        return Puzzle{}, fmt.Errorf("[%s] no puzzle description found", day)
+    }
+
+    if err := writeFile(c.PuzzlePath(year, day), page); err != nil {
+        return Puzzle{}, fmt.Errorf("cache puzzle: %w", err)
+    }
+
+    return puzzle, nil
+}
+
+// GetPuzzle returns the puzzle page of a year and day. Without a session only
+// part 1 is included.
+func GetPuzzle(ctx context.Context, year, day string, session string) ([]byte, error) {
+    req, err := createReq(ctx, http.MethodGet, http.NoBody, session, year, "day", day)
+    if err != nil {
+        return nil, fmt.Errorf("create puzzle request: %w", err)
+    }
+
+    status, body, err := getPage(ctx, req, fmt.Sprintf("[%s]", day), "puzzle")
+    if err != nil {
+        return nil, err
+    }
+    if status == http.StatusNotFound {
+        return nil, fmt.Errorf("[%s]: %w", day, ErrNotFound)
+    }
+
+    return body, nil
+}
+
+// WriteExample stores the example of a puzzle as the example of a day in dir,
+// unless there already is one. Reports whether it was written.
+func WriteExample(dir, day string, puzzle Puzzle) (bool, error) {
+    path := filepath.Join(dir, fmt.Sprintf("day%s.txt", day))
+
+    if _, err := os.Stat(path); err == nil || !errors.Is(err, fs.ErrNotExist) {
+        return false, err
+    }
+
+    example, ok := puzzle.Example()
+    if !ok {
+        return false, nil
+    }
+
+    return true, writeFile(path, []byte(example))
+}
+
+type blockKind int
+
+const (
+    blockParagraph blockKind = iota
+    blockHeading
+    blockCode
+    blockItem
+)
+
+type block struct {
+    kind blockKind
+    text string
+}
+
+// parseBlocks splits an article into headings, paragraphs, code blocks and
+// list items. Inline markup is converted to Markdown or dropped for text.
+func parseBlocks(article string, format Format) []block {
+    blocks := []block{}
+    current := block{blockParagraph, ""}
+    markdown := format == FormatMarkdown
+    links := []string{}
+    inCode := false
+
+    flush := func(next blockKind) {
+        text := current.text
+        if current.kind != blockCode {
+            text = strings.Join(strings.Fields(text), " ")
+        }
+        if strings.TrimSpace(text) != "" {
+            blocks = append(blocks, block{current.kind, text})
+        }
+        current = block{next, ""}
+    }
+
+    for _, match := range reToken.FindAllStringSubmatch(article, -1) {
+        closing, tag, attrs := match[1] == "/", strings.ToLower(match[2]), match[3]
+
+        if tag == "" {
+            current.text += html.UnescapeString(match[0])
+            continue
+        }
+
+        switch tag {
+            case "h2":
+                if closing {
+                    flush(blockParagraph)
+                } else {
+                    flush(blockHeading)
+                }
+            case "p", "ul":
+                flush(blockParagraph)
+            case "pre":
+                if closing {
+                    flush(blockParagraph)
+                } else {
+                    flush(blockCode)
+                }
+            case "li":
+                if closing {
+                    flush(blockParagraph)
+                } else {
+                    flush(blockItem)
+                }
+            case "em":
+                if markdown && current.kind != blockCode && !inCode {
+                    current.text += "**"
+                }
+            case "code":
+                inCode = !closing
+                if markdown && current.kind != blockCode {
+                    current.text += "`"
+                }
+            case "a":
+                if !markdown {
+                    continue
+                }
+                if !closing {
+                    href := ""
+                    if m := reHref.FindStringSubmatch(attrs); m != nil {
+                        href = html.UnescapeString(m[1])
+                    }
+                    if strings.HasPrefix(href, "/") {
+                        href = "https://adventofcode.com" + href
+                    }
+                    links = append(links, href)
+                    current.text += "["
+                } else if len(links) > 0 {
+                    current.text += "](" + links[len(links)-1] + ")"
+                    links = links[:len(links)-1]
+                }
+        }
+    }
+    flush(blockParagraph)
+
+    return blocks
+}
+
+func renderBlocks(blocks []block, format Format) string {
+    sb := strings.Builder{}
+
+    for idx, b := range blocks {
+        if idx > 0 && !(b.kind == blockItem && blocks[idx-1].kind == blockItem) {
+            sb.WriteString("\n")
+        }
+
+        switch {
+            case b.kind == blockHeading && format == FormatMarkdown:
+                sb.WriteString("## " + strings.Trim(b.text, "- ") + "\n")
+            case b.kind == blockCode && format == FormatMarkdown:
+                sb.WriteString("```\n" + strings.TrimRight(b.text, "\n") + "\n```\n")
+            case b.kind == blockItem && format == FormatMarkdown:
+                sb.WriteString("- " + b.text + "\n")
+            case b.kind == blockCode:
+                for _, line := range strings.Split(strings.TrimRight(b.text, "\n"), "\n") {
+                    sb.WriteString("    " + line + "\n")
+                }
+            case b.kind == blockItem:
+                sb.WriteString(wrap(b.text, 80, "  - ", "    "))
+            default:
+                sb.WriteString(wrap(b.text, 80, "", ""))
+        }
+    }
+
+    return sb.String()
+}
+
+// wrap breaks text into lines of at most width characters where possible.
+// The first line starts with first, the others with indent.
+func wrap(text string, width int, first, indent string) string {
+    sb := strings.Builder{}
+    line := first
+    empty := true
+
+    for _, word := range strings.Fields(text) {
+        if !empty && len([]rune(line))+1+len([]rune(word)) > width {
+            sb.WriteString(line + "\n")
+            line = indent
+            empty = true
+        }
+        if !empty {
+            line += " "
+        }
+        line += word
+        empty = false
+    }
+    sb.WriteString(line + "\n")
+
+    return sb.String()
+}
@@ -0,0 +1,161 @@
+package solver
+
+import (
+    "context"
+    "errors"
+    "net/http"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+const (
+    puzzlePart1 = `<html><body><main>
+<article class="day-desc"><h2>--- Day 1: Trebuchet?! ---</h2><p>Something is wrong with global snow production, and you've been selected to take a look. The Elves have even given you a map; on it, they've used stars to mark the top fifty locations that are likely to be having problems.</p>
+<p>For example:</p>
+<pre><code>1abc2
+pqr3stu8vwx
+a1b2c3d4e5f
+treb7uchet
+</code></pre>
+<p>In this example, the calibration values of these four lines are <code>12</code>, <code>38</code>, <code>15</code>, and <code>77</code>. Adding these together produces <code><em>142</em></code>.</p>
+<ul><li>See the <a href="/2023/about">about page</a>.</li><li>Have fun &amp; good luck!</li></ul>
+</article>
+<p>To play, please identify yourself.</p>
+</main></body></html>`
+
+    puzzlePart2 = `<html><body><main>
+<article class="day-desc"><h2>--- Day 1: Trebuchet?! ---</h2><p>Part one.</p></article>
+<p>Your puzzle answer was <code>54239</code>.</p>
+<article class="day-desc"><h2 id="part2">--- Part Two ---</h2><p>Your calculation isn't quite right.</p>
+<pre><code>two1nine
+</code></pre>
+</article>
+</main></body></html>`
+)
+
+func TestPuzzleRender(t *testing.T) {
+    puzzle := ParsePuzzle(puzzlePart1)
+
+    testcases := []TestCase[Format, string]{
+        {FormatText, `--- Day 1: Trebuchet?! ---
+
+Something is wrong with global snow production, and you've been selected to take
+a look. The Elves have even given you a map; on it, they've used stars to mark
+the top fifty locations that are likely to be having problems.
+
+For example:
+
+    1abc2
+    pqr3stu8vwx
+    a1b2c3d4e5f
+    treb7uchet
+
+In this example, the calibration values of these four lines are 12, 38, 15, and
+77. Adding these together produces 142.
+
+  - See the about page.
+  - Have fun & good luck!
+`},
+        {FormatMarkdown, `## Day 1: Trebuchet?!
+
+Something is wrong with global snow production, and you've been selected to take
+a look. The Elves have even given you a map; on it, they've used stars to mark
+the top fifty locations that are likely to be having problems.
+
+For example:
+
+` + "```" + `
+1abc2
+pqr3stu8vwx
+a1b2c3d4e5f
+treb7uchet
+` + "```" + `
+
+In this example, the calibration values of these four lines are ` + "`12`, `38`,\n`15`, and `77`. Adding these together produces `142`." + `
+
+- See the [about page](https://adventofcode.com/2023/about).
+- Have fun & good luck!
+`},
+    }
+
+    for _, tc := range testcases {
+        actual, err := puzzle.Render(tc.input)
+        if err != nil {
+            t.Fatalf("Render(%v) failed: %v", tc.input, err)
+        }
+        if actual != tc.expected {
+            t.Fatalf("Render(%v) =\n%v\nwant\n%v", tc.input, actual, tc.expected)
+        }
+    }
+
+    if _, err := puzzle.Render(FormatCSV); err == nil {
+        t.Fatalf("Render(%v) succeeded, want an error", FormatCSV)
+    }
+}
+
+func TestWriteExample(t *testing.T) {
+    dir := t.TempDir()
+    puzzle := ParsePuzzle(puzzlePart1)
+
+    written, err := WriteExample(dir, "1", puzzle)
+    if err != nil || !written {
+        t.Fatalf("WriteExample = %v, %v, want it written", written, err)
+    }
+
+    data, err := os.ReadFile(filepath.Join(dir, "day1.txt"))
+    if err != nil || string(data) != "1abc2\npqr3stu8vwx\na1b2c3d4e5f\ntreb7uchet\n" {
+        t.Fatalf("example = %q, %v", data, err)
+    }
+
+    written, err = WriteExample(dir, "1", ParsePuzzle(puzzlePart2))
+    if err != nil || written {
+        t.Fatalf("WriteExample over an existing example = %v, %v, want it left alone", written, err)
+    }
+}
+
+func TestFetchPuzzle(t *testing.T) {
+    fake := &fakeClient{status: http.StatusOK, body: puzzlePart1}
+    withClient(t, fake)
+
+    cache := Cache{Dir: t.TempDir()}
+    ctx := context.Background()
+
+    puzzle, err := cache.FetchPuzzle(ctx, DefaultYear, "1", "", false)
+    if err != nil || len(puzzle.Articles) != 1 || fake.requests[0].URL.Path != "/2023/day/1" {
+        t.Fatalf("FetchPuzzle(1) = %v articles, %v", len(puzzle.Articles), err)
+    }
+
+    // without a session, part 2 cannot be unlocked
+    if _, err := cache.FetchPuzzle(ctx, DefaultYear, "1", "", false); err != nil || len(fake.requests) != 1 {
+        t.Fatalf("FetchPuzzle(1) again = %v after %v requests, want it cached", err, len(fake.requests))
+    }
+
+    fake.body = puzzlePart2
+    puzzle, err = cache.FetchPuzzle(ctx, DefaultYear, "1", "secret", false)
+    if err != nil || len(puzzle.Articles) != 2 || len(fake.requests) != 2 {
+        t.Fatalf("FetchPuzzle(1) with session = %v articles, %v after %v requests, want part 2", len(puzzle.Articles), err, len(fake.requests))
+    }
+
+    if _, err := cache.FetchPuzzle(ctx, DefaultYear, "1", "secret", false); err != nil || len(fake.requests) != 2 {
+        t.Fatalf("FetchPuzzle(1) with both parts = %v after %v requests, want it cached", err, len(fake.requests))
+    }
+}
+
+func TestGetPuzzle(t *testing.T) {
+    withClock(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+    loggedOut := puzzlePart1 + "<p>Please log in to get your puzzle input.</p>"
+
+    fake := &sequenceClient{responses: []fakeResponse{{status: http.StatusBadGateway}, {status: http.StatusOK, body: loggedOut}}}
+    withClient(t, fake)
+
+    page, err := GetPuzzle(context.Background(), DefaultYear, "1", "")
+    if err != nil || string(page) != loggedOut || len(fake.requests) != 2 {
+        t.Fatalf("GetPuzzle(1) = %v after %v requests, want the page after %v", err, len(fake.requests), 2)
+    }
+
+    if _, err := GetPuzzle(context.Background(), DefaultYear, "1", "expired"); !errors.Is(err, ErrUnauthorized) {
+        t.Fatalf("GetPuzzle(1) with an expired session = %v, want %v", err, ErrUnauthorized)
+    }
+}
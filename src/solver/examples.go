@@ -0,0 +1,126 @@
+package solver
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "regexp"
+    "sort"
+    "strings"
+)
+
+var (
+    reExample = regexp.MustCompile(`^day(\d+)(?:-(\d+))?\.txt$`)
+)
+
+// Example is a puzzle example, e.g. examples/day1.txt, with its expected
+// answers. A day can have several examples, numbered as variants like
+// examples/day1-2.txt.
+//
+// The expected answers live in a sidecar file next to the example, named
+// after it with an .answers.json extension (examples/day1-2.answers.json) and
+// in the same format as the recorded answers of verify:
+//
+//     {"part2": "281"}
+//
+// Parts without an expected answer are not run, so a variant only has to
+// mention the part it was written for.
+type Example struct {
+    Day string
+    Variant string
+    Path string
+    Expected Answers
+}
+
+// Name returns the example file name without extension, e.g. day1-2.
+func (e Example) Name() string {
+    return strings.TrimSuffix(filepath.Base(e.Path), ".txt")
+}
+
+// ExpectedPath returns the path of the sidecar file holding the expected
+// answers of an example.
+func ExpectedPath(example string) string {
+    return strings.TrimSuffix(example, ".txt") + ".answers.json"
+}
+
+// Examples discovers all examples in dir, together with their expected
+// answers, ordered by day and variant. Examples without a sidecar file have
+// no expected answers.
+func Examples(dir string) ([]Example, error) {
+    entries, err := os.ReadDir(dir)
+    if err != nil {
+        return nil, err
+    }
+
+    examples := []Example{}
+    for _, entry := range entries {
+        match := reExample.FindStringSubmatch(entry.Name())
+        if entry.IsDir() || match == nil {
+            continue
+        }
+
+        example := Example{
+            Day: match[1],
+            Variant: match[2],
+            Path: filepath.Join(dir, entry.Name()),
+        }
+
+        data, err := os.ReadFile(ExpectedPath(example.Path))
+        if err != nil && !errors.Is(err, fs.ErrNotExist) {
+            return nil, err
+        }
+        if err == nil {
+            if err := json.Unmarshal(data, &example.Expected); err != nil {
+                return nil, fmt.Errorf("parse %s: %w", ExpectedPath(example.Path), err)
+            }
+        }
+
+        examples = append(examples, example)
+    }
+
+    sort.Slice(examples, func(i, j int) bool {
+        if examples[i].Day != examples[j].Day {
+            return dayLess(examples[i].Day, examples[j].Day)
+        }
+        return dayLess(examples[i].Variant, examples[j].Variant)
+    })
+
+    return examples, nil
+}
+
+// Check solves the parts of an example that have an expected answer with the
+// registered solver of its day and compares the answers.
+func (e Example) Check() ([]Mismatch, error) {
+    s, err := GetSolver(e.Day)
+    if err != nil {
+        return nil, err
+    }
+
+    file, err := os.Open(e.Path)
+    if err != nil {
+        return nil, err
+    }
+    defer file.Close()
+
+    lines, err := ReadLines(file)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read: %w", err)
+    }
+
+    res := Result{Name: e.Name()}
+    if e.Expected.Part1 != "" {
+        if res.Part1, err = s.Part1(lines); err != nil {
+            return nil, fmt.Errorf("part 1: %w", err)
+        }
+    }
+    if e.Expected.Part2 != "" {
+        if res.Part2, err = s.Part2(lines); err != nil {
+            return nil, fmt.Errorf("part 2: %w", err)
+        }
+    }
+
+    return e.Expected.Check(res), nil
+}
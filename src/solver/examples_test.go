@@ -0,0 +1,45 @@
+package solver
+
+import (
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+func TestExamples(t *testing.T) {
+    dir := t.TempDir()
+    files := map[string]string{
+        "day2.txt": "",
+        "day10.txt": "",
+        "day1-2.txt": "",
+        "day1-2.answers.json": `{"part2": "281"}`,
+        "day1.txt": "",
+        "day1.answers.json": `{"part1": "142"}`,
+        "notes.md": "",
+    }
+    for name, content := range files {
+        if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    examples, err := Examples(dir)
+    if err != nil {
+        t.Fatalf("Examples failed: %v", err)
+    }
+
+    expected := []Example{
+        {"1", "", filepath.Join(dir, "day1.txt"), Answers{Part1: "142"}},
+        {"1", "2", filepath.Join(dir, "day1-2.txt"), Answers{Part2: "281"}},
+        {"2", "", filepath.Join(dir, "day2.txt"), Answers{}},
+        {"10", "", filepath.Join(dir, "day10.txt"), Answers{}},
+    }
+    if len(examples) != len(expected) {
+        t.Fatalf("Examples = %v, want %v", examples, expected)
+    }
+    for idx, example := range examples {
+        if example != expected[idx] {
+            t.Fatalf("Examples[%d] = %v, want %v", idx, example, expected[idx])
+        }
+    }
+}
@@ -0,0 +1,158 @@
+package solver
+
+import (
+    "encoding/csv"
+    "encoding/json"
+    "fmt"
+    "io"
+    "strconv"
+    "strings"
+)
+
+// Format is a way of writing results.
+type Format string
+
+const (
+    FormatText = Format("text")
+    FormatJSON = Format("json")
+    FormatCSV = Format("csv")
+    FormatMarkdown = Format("markdown")
+)
+
+// Formats lists all supported formats.
+func Formats() []Format {
+    return []Format{FormatText, FormatJSON, FormatCSV, FormatMarkdown}
+}
+
+// ParseFormat returns the format with the given name.
+func ParseFormat(name string) (Format, error) {
+    for _, format := range Formats() {
+        if string(format) == strings.ToLower(name) {
+            return format, nil
+        }
+    }
+    return "", fmt.Errorf("unknown format %q, expected one of %v", name, Formats())
+}
+
+// record is the structured form of a Result. Durations are in nanoseconds and
+// left out when they were not measured.
+type record struct {
+    Day string `json:"day"`
+    Part1 string `json:"part1"`
+    Part2 string `json:"part2"`
+    Time1 *int64 `json:"time1_ns,omitempty"`
+    Time2 *int64 `json:"time2_ns,omitempty"`
+    Status Status `json:"status"`
+    Error string `json:"error,omitempty"`
+}
+
+func newRecord(r Result) record {
+    rec := record{r.Name, r.Part1, r.Part2, nil, nil, r.Status, ""}
+
+    if rec.Part1 == "" {
+        rec.Part1 = Unsolved
+    }
+    if rec.Part2 == "" {
+        rec.Part2 = Unsolved
+    }
+    if rec.Status == "" {
+        rec.Status = Status(Unknown)
+    }
+    if len(r.Elapsed) > 0 {
+        ns := r.Elapsed[0].Nanoseconds()
+        rec.Time1 = &ns
+    }
+    if len(r.Elapsed) > 1 {
+        ns := r.Elapsed[1].Nanoseconds()
+        rec.Time2 = &ns
+    }
+    if r.Err != nil {
+        rec.Error = r.Err.Error()
+    }
+
+    return rec
+}
+
+func (rec record) times() (string, string) {
+    time1, time2 := "", ""
+    if rec.Time1 != nil {
+        time1 = strconv.FormatInt(*rec.Time1, 10)
+    }
+    if rec.Time2 != nil {
+        time2 = strconv.FormatInt(*rec.Time2, 10)
+    }
+    return time1, time2
+}
+
+// WriteResults writes the results in the given format. Text writes the usual
+// tab separated lines, JSON writes one object per line, CSV and Markdown
+// write a table with a header.
+func WriteResults(w io.Writer, format Format, results []Result) error {
+    switch format {
+        case FormatText:
+            for _, r := range results {
+                if _, err := fmt.Fprintln(w, r); err != nil {
+                    return err
+                }
+            }
+            return nil
+        case FormatJSON:
+            return writeJSON(w, results)
+        case FormatCSV:
+            return writeCSV(w, results)
+        case FormatMarkdown:
+            return writeMarkdown(w, results)
+    }
+    return fmt.Errorf("unknown format %q, expected one of %v", format, Formats())
+}
+
+func writeJSON(w io.Writer, results []Result) error {
+    encoder := json.NewEncoder(w)
+    for _, r := range results {
+        if err := encoder.Encode(newRecord(r)); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+func writeCSV(w io.Writer, results []Result) error {
+    cw := csv.NewWriter(w)
+
+    cw.Write([]string{"day", "part1", "part2", "time1_ns", "time2_ns", "status", "error"})
+    for _, r := range results {
+        rec := newRecord(r)
+        time1, time2 := rec.times()
+        cw.Write([]string{rec.Day, rec.Part1, rec.Part2, time1, time2, string(rec.Status), rec.Error})
+    }
+
+    cw.Flush()
+    return cw.Error()
+}
+
+func writeMarkdown(w io.Writer, results []Result) error {
+    cell := strings.NewReplacer("|", "\\|", "\n", " ")
+
+    fmt.Fprintln(w, "| Day | Part 1 | Part 2 | Time 1 | Time 2 | Status | Error |")
+    fmt.Fprintln(w, "|----:|-------:|-------:|-------:|-------:|--------|-------|")
+
+    for _, r := range results {
+        rec := newRecord(r)
+        time1, time2 := "", ""
+        if len(r.Elapsed) > 0 {
+            time1 = r.Elapsed[0].String()
+        }
+        if len(r.Elapsed) > 1 {
+            time2 = r.Elapsed[1].String()
+        }
+
+        _, err := fmt.Fprintf(w, "| %v | %v | %v | %v | %v | %v | %v |\n",
+            cell.Replace(rec.Day), cell.Replace(rec.Part1), cell.Replace(rec.Part2),
+            time1, time2, rec.Status, cell.Replace(rec.Error))
+        if err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
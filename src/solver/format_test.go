@@ -0,0 +1,54 @@
+package solver
+
+import (
+    "bytes"
+    "errors"
+    "testing"
+    "time"
+)
+
+type (
+    TestCase[I any, E any] struct {
+        input I
+        expected E
+    }
+)
+
+func TestWriteResults(t *testing.T) {
+    results := []Result{
+        {"14", "136", "64", []time.Duration{1500 * time.Nanosecond, 2 * time.Millisecond}, StatusSolved, nil},
+        {"6", "288", Unsolved, nil, StatusError, errors.New("bad | input")},
+    }
+
+    testcases := []TestCase[Format, string]{
+        {FormatText, "14\t136\t64\t1.5µs\t2ms\n6\t288\tunsolved\n"},
+        {FormatJSON, `{"day":"14","part1":"136","part2":"64","time1_ns":1500,"time2_ns":2000000,"status":"solved"}
+{"day":"6","part1":"288","part2":"unsolved","status":"error","error":"bad | input"}
+`},
+        {FormatCSV, "day,part1,part2,time1_ns,time2_ns,status,error\n14,136,64,1500,2000000,solved,\n6,288,unsolved,,,error,bad | input\n"},
+        {FormatMarkdown, `| Day | Part 1 | Part 2 | Time 1 | Time 2 | Status | Error |
+|----:|-------:|-------:|-------:|-------:|--------|-------|
+| 14 | 136 | 64 | 1.5µs | 2ms | solved |  |
+| 6 | 288 | unsolved |  |  | error | bad \| input |
+`},
+    }
+
+    for _, tc := range testcases {
+        buf := bytes.Buffer{}
+        if err := WriteResults(&buf, tc.input, results); err != nil {
+            t.Fatalf("WriteResults(%v) failed: %v", tc.input, err)
+        }
+        if buf.String() != tc.expected {
+            t.Fatalf("WriteResults(%v) =\n%v\nwant\n%v", tc.input, buf.String(), tc.expected)
+        }
+    }
+}
+
+func TestParseFormat(t *testing.T) {
+    if format, err := ParseFormat("JSON"); err != nil || format != FormatJSON {
+        t.Fatalf("ParseFormat(JSON) = %v, %v, want %v", format, err, FormatJSON)
+    }
+    if _, err := ParseFormat("yaml"); err == nil {
+        t.Fatalf("ParseFormat(yaml) succeeded, want an error")
+    }
+}
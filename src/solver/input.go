@@ -2,6 +2,7 @@
 package solver
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
 	"time"
         "bufio"
         "strings"
@@ -22,8 +24,67 @@ var (
 	ErrNotFound = errors.New("puzzle input not found")
 	// ErrUnauthorized returns when session is empty or invalid.
 	ErrUnauthorized = errors.New("unauthorized")
+	// ErrNotUnlocked returns when the puzzle is not released yet. The error
+	// is an UnlockError telling how long to wait.
+	ErrNotUnlocked = errors.New("puzzle not unlocked yet")
+
+	// UserAgent identifies this tool to AoC, as they ask automated tools to do.
+	UserAgent = "github.com/wthys/advent-of-code-2023 by wim.thys@zardof.be"
+
+	// Retry determines how requests for inputs are retried.
+	Retry = RetryPolicy{Attempts: 3, Timeout: 5 * time.Second, Backoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	// release is the time zone AoC releases puzzles in, at midnight.
+	release = time.FixedZone("EST", -5*60*60)
 )
 
+// RetryPolicy makes up to Attempts requests, each taking at most Timeout,
+// when AoC has a server error or cannot be reached. The wait between attempts
+// starts at Backoff and doubles every time, up to MaxBackoff.
+type RetryPolicy struct {
+	Attempts   int
+	Timeout    time.Duration
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// UnlockError tells when a puzzle that is not released yet will be.
+type UnlockError struct {
+	Year   string
+	Day    string
+	Unlock time.Time
+	Wait   time.Duration
+}
+
+func (e UnlockError) Error() string {
+	return fmt.Sprintf("[%s/%s] %v, unlocks in %v at %v", e.Year, e.Day, ErrNotUnlocked, e.Wait.Round(time.Second), e.Unlock.Format("2006-01-02 15:04 MST"))
+}
+
+func (e UnlockError) Unwrap() []error {
+	return []error{ErrNotUnlocked, ErrNotFound}
+}
+
+// UnlockTime returns when the puzzle of a year and day is released.
+func UnlockTime(year, day string) (time.Time, error) {
+	y, err := strconv.Atoi(year)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid year %q: %w", year, err)
+	}
+	d, err := strconv.Atoi(day)
+	if err != nil || d < 1 || d > 25 {
+		return time.Time{}, fmt.Errorf("invalid day %q, expected 1 to 25", day)
+	}
+	return time.Date(y, time.December, d, 0, 0, 0, 0, release), nil
+}
+
+func unlockError(year, day string) (UnlockError, bool) {
+	unlock, err := UnlockTime(year, day)
+	if err != nil {
+		return UnlockError{}, false
+	}
+	wait := unlock.Sub(SystemClock.Now())
+	return UnlockError{year, day, unlock, max(wait, 0)}, wait > 0
+}
 
 // ClientDo provides the interface for custom HTTP client implementations.
 type ClientDo interface {
@@ -33,25 +94,71 @@ type ClientDo interface {
 // Client is the default Client and is used by Get, Head, and Post.
 var Client ClientDo = http.DefaultClient
 
-// Get returns puzzle input.
-func GetInput(ctx context.Context, d string, session string) ([]byte, error) {
-	req, err := createInputReq(ctx, d, session)
+// Get returns puzzle input of a year and day. Puzzles that are not released
+// yet are not requested and result in an UnlockError. Server errors and
+// network problems are retried according to Retry.
+func GetInput(ctx context.Context, year, d string, session string) ([]byte, error) {
+	if unlock, locked := unlockError(year, d); locked {
+		return nil, unlock
+	}
+
+	req, err := createInputReq(ctx, year, d, session)
 	if err != nil {
 		return nil, fmt.Errorf("create input request: %w", err)
 	}
 
-	const (
-		timeoutSecs = 5
-	)
+	status, body, err := getPage(ctx, req, fmt.Sprintf("[%s]", d), "puzzle input")
+	if err != nil {
+		return nil, err
+	}
 
-	ctx, cancel := context.WithTimeout(ctx, time.Second*timeoutSecs)
-	defer cancel()
+	if status == http.StatusNotFound {
+		unlock, locked := unlockError(year, d)
+		if !bytes.Contains(body, []byte("before it unlocks")) && !locked {
+			return nil, fmt.Errorf("[%s]: %w", d, ErrNotFound)
+		}
+		if unlock.Unlock.IsZero() {
+			// AoC knows better when the year or day does not parse
+			return nil, fmt.Errorf("[%s]: %w: %w", d, ErrNotUnlocked, ErrNotFound)
+		}
+		return nil, unlock
+	}
+
+	return body, nil
+}
+
+// getPage requests a page of AoC, retrying server errors and network problems
+// according to Retry. Being rate limited is not retried, as that only makes
+// it worse. A session that AoC does not accept results in ErrUnauthorized,
+// whether it tells with the status or by asking to log in; any other bad
+// request is a plain failure. Returns the status
+// and body when the page is found or not found; tag and what describe the
+// page in errors.
+func getPage(ctx context.Context, req *http.Request, tag, what string) (int, []byte, error) {
+	backoff := Retry.Backoff
+	for attempt := 1; ; attempt++ {
+		status, body, retry, err := getPageOnce(ctx, req, tag, what)
+		if !retry || attempt >= Retry.Attempts {
+			return status, body, err
+		}
+
+		log.WithError(ctx, err).Warn(fmt.Sprintf("Failed to get %s on attempt %d, retrying", what, attempt))
+		if err := SystemClock.Sleep(ctx, backoff); err != nil {
+			return 0, nil, err
+		}
+		backoff = min(2*backoff, Retry.MaxBackoff)
+	}
+}
 
-	req = req.Clone(ctx)
+// getPageOnce makes a single request for a page, and reports whether it is
+// worth retrying when it fails.
+func getPageOnce(ctx context.Context, req *http.Request, tag, what string) (int, []byte, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, Retry.Timeout)
+	defer cancel()
 
-	resp, err := Client.Do(req)
+	resp, err := Client.Do(req.Clone(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("send request: %w", err)
+		return 0, nil, req.Context().Err() == nil, fmt.Errorf("send request: %w", err)
 	}
 
 	defer func() {
@@ -62,21 +169,31 @@ func GetInput(ctx context.Context, d string, session string) ([]byte, error) {
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("read responsse body: %w", err)
+		return 0, nil, true, fmt.Errorf("read response body: %w", err)
 	}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		return body, nil
-	case http.StatusNotFound:
-		return nil, fmt.Errorf("[%s]: %w", d, ErrNotFound)
-	case http.StatusBadRequest:
-		return nil, ErrUnauthorized
+	if sessionRejected(req, body) {
+		return 0, nil, false, fmt.Errorf("%s session not accepted: %w", tag, ErrUnauthorized)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK, resp.StatusCode == http.StatusNotFound:
+		return resp.StatusCode, body, false, nil
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		return 0, nil, false, fmt.Errorf("%s session not accepted: %w", tag, ErrUnauthorized)
+	case resp.StatusCode >= 500:
+		return 0, nil, true, fmt.Errorf("%s failed to get %s[%s]", tag, what, resp.Status)
 	default:
-		return nil, fmt.Errorf("[%s] failed to get puzzle input[%s]", d, resp.Status)
+		return 0, nil, false, fmt.Errorf("%s failed to get %s[%s]", tag, what, resp.Status)
 	}
 }
-
+// sessionRejected reports whether AoC asks to log in although the request
+// sent a session. Pages for anyone not logged in ask to log in as well, so
+// the body alone does not tell.
+func sessionRejected(req *http.Request, body []byte) bool {
+	session, err := req.Cookie("session")
+	return err == nil && session.Value != "" && bytes.Contains(body, []byte("Please log in"))
+}
 
 func ReadLines(r io.Reader) ([]string, error) {
     rdr := bufio.NewReader(r)
@@ -106,11 +223,20 @@ func ReadLines(r io.Reader) ([]string, error) {
 
 // createInputReq creates an HTTP request for retrieving the Advent of Code
 // input given year/day.
-func createInputReq(ctx context.Context, d string, sessionID string) (*http.Request, error) {
+func createInputReq(ctx context.Context, year, d string, sessionID string) (*http.Request, error) {
+	const (
+		day   = "day"
+		input = "input"
+	)
+
+	return createReq(ctx, http.MethodGet, http.NoBody, sessionID, year, day, d, input)
+}
+
+// createReq creates an authenticated HTTP request for the Advent of Code page
+// at the given path elements.
+func createReq(ctx context.Context, method string, body io.Reader, sessionID string, elems ...string) (*http.Request, error) {
 	const (
 		baseurl = "https://adventofcode.com"
-		day     = "day"
-		input   = "input"
 	)
 
 	u, err := url.Parse(baseurl)
@@ -118,9 +244,9 @@ func createInputReq(ctx context.Context, d string, sessionID string) (*http.Requ
 		return nil, fmt.Errorf("parse base url: %w", err)
 	}
 
-	u.Path = path.Join(u.Path, "2023", day, d, input)
+	u.Path = path.Join(append([]string{u.Path}, elems...)...)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -140,8 +266,7 @@ func createInputReq(ctx context.Context, d string, sessionID string) (*http.Requ
 		Unparsed:   nil,
 	})
 
-	req.Header.Set("User-Agent",
-		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/96.0.4664.93 Safari/537.36 (github.com/wthys/advent-of-code-2023 by wim.thys@zardof.be)")
+	req.Header.Set("User-Agent", UserAgent)
 
 	return req, nil
 }
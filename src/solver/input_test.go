@@ -0,0 +1,215 @@
+package solver
+
+import (
+    "context"
+    "errors"
+    "io"
+    "net/http"
+    "strings"
+    "testing"
+    "time"
+)
+
+type fakeResponse struct {
+    status int
+    body string
+    err error
+}
+
+// sequenceClient answers requests with its responses in order, repeating the
+// last one.
+type sequenceClient struct {
+    responses []fakeResponse
+    requests []*http.Request
+}
+
+func (f *sequenceClient) Do(req *http.Request) (*http.Response, error) {
+    f.requests = append(f.requests, req)
+    resp := f.responses[min(len(f.requests), len(f.responses))-1]
+    if resp.err != nil {
+        return nil, resp.err
+    }
+    return &http.Response{
+        StatusCode: resp.status,
+        Status: http.StatusText(resp.status),
+        Body: io.NopCloser(strings.NewReader(resp.body)),
+        Header: http.Header{},
+        Request: req,
+    }, nil
+}
+
+// fakeClock only moves forward when slept on.
+type fakeClock struct {
+    now time.Time
+    slept []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+    return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+    if err := ctx.Err(); err != nil {
+        return err
+    }
+    c.slept = append(c.slept, d)
+    c.now = c.now.Add(d)
+    return nil
+}
+
+func withClock(t *testing.T, now time.Time) *fakeClock {
+    clock := &fakeClock{now: now}
+    previous := SystemClock
+    SystemClock = clock
+    t.Cleanup(func() {
+        SystemClock = previous
+    })
+    return clock
+}
+
+func TestUnlockTime(t *testing.T) {
+    tests := []TestCase[[2]string, time.Time]{
+        {[2]string{"2023", "1"}, time.Date(2023, time.December, 1, 5, 0, 0, 0, time.UTC)},
+        {[2]string{"2023", "25"}, time.Date(2023, time.December, 25, 5, 0, 0, 0, time.UTC)},
+        {[2]string{"2015", "09"}, time.Date(2015, time.December, 9, 5, 0, 0, 0, time.UTC)},
+    }
+
+    for _, test := range tests {
+        actual, err := UnlockTime(test.input[0], test.input[1])
+        if err != nil || !actual.Equal(test.expected) {
+            t.Fatalf("UnlockTime(%v, %v) = %v, %v, want %v", test.input[0], test.input[1], actual, err, test.expected)
+        }
+    }
+
+    for _, day := range []string{"0", "26", "x"} {
+        if _, err := UnlockTime("2023", day); err == nil {
+            t.Fatalf("UnlockTime(2023, %v) succeeded, want an error", day)
+        }
+    }
+}
+
+func TestGetInputBeforeUnlock(t *testing.T) {
+    clock := withClock(t, time.Date(2023, time.December, 5, 4, 30, 0, 0, time.UTC))
+    fake := &sequenceClient{responses: []fakeResponse{{status: http.StatusOK, body: "1 2 3\n"}}}
+    withClient(t, fake)
+
+    _, err := GetInput(context.Background(), "2023", "5", "secret")
+
+    unlock := UnlockError{}
+    if !errors.As(err, &unlock) || unlock.Wait != 30*time.Minute || len(fake.requests) != 0 {
+        t.Fatalf("GetInput(5) at %v = %v after %v requests, want to wait %v without requests", clock.now, err, len(fake.requests), 30*time.Minute)
+    }
+    if !errors.Is(err, ErrNotUnlocked) || !errors.Is(err, ErrNotFound) {
+        t.Fatalf("GetInput(5) = %v, want both %v and %v", err, ErrNotUnlocked, ErrNotFound)
+    }
+}
+
+func TestGetInputStatus(t *testing.T) {
+    tests := []TestCase[fakeResponse, error]{
+        {fakeResponse{status: http.StatusOK, body: "1 2 3\n"}, nil},
+        {fakeResponse{status: http.StatusBadRequest, body: "Puzzle inputs differ by user.  Please log in to get your puzzle input.\n"}, ErrUnauthorized},
+        {fakeResponse{status: http.StatusOK, body: "<html><body>Please log in to get your puzzle input.</body></html>"}, ErrUnauthorized},
+        {fakeResponse{status: http.StatusUnauthorized}, ErrUnauthorized},
+        {fakeResponse{status: http.StatusForbidden}, ErrUnauthorized},
+        {fakeResponse{status: http.StatusNotFound, body: "404 Not Found\n"}, ErrNotFound},
+        {fakeResponse{status: http.StatusNotFound, body: "Please don't repeatedly request this endpoint before it unlocks!"}, ErrNotUnlocked},
+    }
+
+    withClock(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+    for _, test := range tests {
+        fake := &sequenceClient{responses: []fakeResponse{test.input}}
+        withClient(t, fake)
+
+        body, err := GetInput(context.Background(), "2023", "5", "secret")
+        if !errors.Is(err, test.expected) || (err == nil && string(body) != test.input.body) {
+            t.Fatalf("GetInput(5) with %v %q = %q, %v, want %v", test.input.status, test.input.body, body, err, test.expected)
+        }
+        if len(fake.requests) != 1 {
+            t.Fatalf("GetInput(5) with %v sent %v requests, want %v", test.input.status, len(fake.requests), 1)
+        }
+    }
+}
+
+func TestGetInputBadRequest(t *testing.T) {
+    withClock(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+    fake := &sequenceClient{responses: []fakeResponse{{status: http.StatusBadRequest, body: "400 Bad Request\n"}}}
+    withClient(t, fake)
+
+    _, err := GetInput(context.Background(), "2023", "5", "secret")
+    if err == nil || errors.Is(err, ErrUnauthorized) || len(fake.requests) != 1 {
+        t.Fatalf("GetInput(5) with a bad request = %v after %v requests, want a failure other than %v", err, len(fake.requests), ErrUnauthorized)
+    }
+}
+
+func TestGetInputRetries(t *testing.T) {
+    clock := withClock(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+    fake := &sequenceClient{responses: []fakeResponse{
+        {err: errors.New("connection reset")},
+        {status: http.StatusBadGateway},
+        {status: http.StatusOK, body: "1 2 3\n"},
+    }}
+    withClient(t, fake)
+
+    body, err := GetInput(context.Background(), "2023", "5", "secret")
+    if err != nil || string(body) != "1 2 3\n" || len(fake.requests) != 3 {
+        t.Fatalf("GetInput(5) = %q, %v after %v requests, want %q after %v", body, err, len(fake.requests), "1 2 3\n", 3)
+    }
+
+    expected := []time.Duration{Retry.Backoff, 2 * Retry.Backoff}
+    if len(clock.slept) != len(expected) || clock.slept[0] != expected[0] || clock.slept[1] != expected[1] {
+        t.Fatalf("GetInput(5) waited %v, want %v", clock.slept, expected)
+    }
+}
+
+func TestGetInputGivesUp(t *testing.T) {
+    withClock(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+    fake := &sequenceClient{responses: []fakeResponse{{status: http.StatusServiceUnavailable}}}
+    withClient(t, fake)
+
+    _, err := GetInput(context.Background(), "2023", "5", "secret")
+    if err == nil || len(fake.requests) != Retry.Attempts {
+        t.Fatalf("GetInput(5) = %v after %v requests, want an error after %v", err, len(fake.requests), Retry.Attempts)
+    }
+}
+
+func TestGetInputRateLimited(t *testing.T) {
+    clock := withClock(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+    fake := &sequenceClient{responses: []fakeResponse{{status: http.StatusTooManyRequests}}}
+    withClient(t, fake)
+
+    _, err := GetInput(context.Background(), "2023", "5", "secret")
+    if err == nil || len(fake.requests) != 1 || len(clock.slept) != 0 {
+        t.Fatalf("GetInput(5) = %v after %v requests and waiting %v, want an error after %v without waiting", err, len(fake.requests), clock.slept, 1)
+    }
+}
+
+func TestGetInputNotUnlockedInvalidYear(t *testing.T) {
+    withClock(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+    fake := &sequenceClient{responses: []fakeResponse{{status: http.StatusNotFound, body: "Please don't repeatedly request this endpoint before it unlocks!"}}}
+    withClient(t, fake)
+
+    _, err := GetInput(context.Background(), "abc", "5", "secret")
+    if !errors.Is(err, ErrNotUnlocked) || errors.As(err, &UnlockError{}) {
+        t.Fatalf("GetInput(abc/5) = %v, want %v without an unlock time", err, ErrNotUnlocked)
+    }
+}
+
+func TestGetInputUserAgent(t *testing.T) {
+    withClock(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+    fake := &sequenceClient{responses: []fakeResponse{{status: http.StatusOK, body: "1\n"}}}
+    withClient(t, fake)
+
+    previous := UserAgent
+    UserAgent = "example.com/aoc by someone@example.com"
+    t.Cleanup(func() {
+        UserAgent = previous
+    })
+
+    if _, err := GetInput(context.Background(), "2023", "5", "secret"); err != nil {
+        t.Fatalf("GetInput(5) = %v, want %v", err, nil)
+    }
+    if actual := fake.requests[0].Header.Get("User-Agent"); actual != UserAgent {
+        t.Fatalf("GetInput(5) sent User-Agent %q, want %q", actual, UserAgent)
+    }
+}
@@ -0,0 +1,265 @@
+package solver
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "io/fs"
+    "net/http"
+    "os"
+    "path/filepath"
+    "sort"
+    "strconv"
+    "strings"
+    "text/tabwriter"
+    "time"
+)
+
+const (
+    // LeaderboardInterval is how long AoC asks to wait between requests for
+    // the same private leaderboard.
+    LeaderboardInterval = 15 * time.Minute
+)
+
+// Star records when a part of a day was solved.
+type Star struct {
+    GetStarTS int64 `json:"get_star_ts"`
+    StarIndex int64 `json:"star_index"`
+}
+
+// Member is a participant on a private leaderboard.
+type Member struct {
+    ID int `json:"id"`
+    Name string `json:"name"`
+    Stars int `json:"stars"`
+    LocalScore int `json:"local_score"`
+    GlobalScore int `json:"global_score"`
+    LastStarTS int64 `json:"last_star_ts"`
+    CompletionDayLevel map[string]map[string]Star `json:"completion_day_level"`
+}
+
+// Leaderboard is a private leaderboard as returned by AoC.
+type Leaderboard struct {
+    OwnerID int `json:"owner_id"`
+    Event string `json:"event"`
+    Members map[string]Member `json:"members"`
+}
+
+// DisplayName returns the name of a member, or a placeholder for anonymous
+// members.
+func (m Member) DisplayName() string {
+    if m.Name == "" {
+        return fmt.Sprintf("(anonymous user #%d)", m.ID)
+    }
+    return m.Name
+}
+
+// Parts returns how many parts of a day a member solved.
+func (m Member) Parts(day int) int {
+    return len(m.CompletionDayLevel[strconv.Itoa(day)])
+}
+
+// Delta returns how long a member took from solving part 1 of a day to
+// solving part 2, or false when part 2 is not solved.
+func (m Member) Delta(day int) (time.Duration, bool) {
+    levels := m.CompletionDayLevel[strconv.Itoa(day)]
+    part1, ok1 := levels["1"]
+    part2, ok2 := levels["2"]
+    if !ok1 || !ok2 {
+        return 0, false
+    }
+    return time.Duration(part2.GetStarTS - part1.GetStarTS) * time.Second, true
+}
+
+// Ranked returns the members ordered by local score, then stars, then who got
+// their last star first.
+func (lb Leaderboard) Ranked() []Member {
+    members := []Member{}
+    for _, m := range lb.Members {
+        members = append(members, m)
+    }
+
+    sort.Slice(members, func(i, j int) bool {
+        a, b := members[i], members[j]
+        switch {
+            case a.LocalScore != b.LocalScore:
+                return a.LocalScore > b.LocalScore
+            case a.Stars != b.Stars:
+                return a.Stars > b.Stars
+            case a.LastStarTS != b.LastStarTS:
+                return a.LastStarTS < b.LastStarTS
+            default:
+                return a.ID < b.ID
+        }
+    })
+
+    return members
+}
+
+// LastDay returns the last day any member got a star for.
+func (lb Leaderboard) LastDay() int {
+    last := 0
+    for _, m := range lb.Members {
+        for day := range m.CompletionDayLevel {
+            if d, err := strconv.Atoi(day); err == nil && d > last {
+                last = d
+            }
+        }
+    }
+    return last
+}
+
+// LeaderboardPath returns where a private leaderboard of a year is cached.
+// The id should be checked with ValidLeaderboardID first.
+func (c Cache) LeaderboardPath(year, id string) string {
+    return filepath.Join(c.Dir, year, fmt.Sprintf("leaderboard-%s.json", id))
+}
+
+// ValidLeaderboardID checks that id is the number of a private leaderboard,
+// as it ends up in paths and URLs.
+func ValidLeaderboardID(id string) error {
+    if n, err := strconv.ParseUint(id, 10, 64); err != nil || strconv.FormatUint(n, 10) != id {
+        return fmt.Errorf("invalid leaderboard id %q, expected a number", id)
+    }
+    return nil
+}
+
+// FetchLeaderboard returns a private leaderboard of a year, along with the
+// time it was retrieved. It is only retrieved again when the cached copy is
+// older than LeaderboardInterval.
+func (c Cache) FetchLeaderboard(ctx context.Context, year, id string, session string) (Leaderboard, time.Time, error) {
+    if err := ValidLeaderboardID(id); err != nil {
+        return Leaderboard{}, time.Time{}, err
+    }
+
+    path := c.LeaderboardPath(year, id)
+
+    info, err := os.Stat(path)
+    if err != nil && !errors.Is(err, fs.ErrNotExist) {
+        return Leaderboard{}, time.Time{}, err
+    }
+
+    if err == nil && SystemClock.Now().Sub(info.ModTime()) < LeaderboardInterval {
+        data, err := os.ReadFile(path)
+        if err != nil {
+            return Leaderboard{}, time.Time{}, fmt.Errorf("read cached leaderboard: %w", err)
+        }
+        lb, err := ParseLeaderboard(data)
+        return lb, info.ModTime(), err
+    }
+
+    data, err := GetLeaderboard(ctx, year, id, session)
+    if err != nil {
+        return Leaderboard{}, time.Time{}, err
+    }
+
+    lb, err := ParseLeaderboard(data)
+    if err != nil {
+        return Leaderboard{}, time.Time{}, err
+    }
+
+    if err := writeFile(path, data); err != nil {
+        return Leaderboard{}, time.Time{}, fmt.Errorf("cache leaderboard: %w", err)
+    }
+
+    // the modification time tells when it was fetched
+    fetched := SystemClock.Now()
+    if err := os.Chtimes(path, fetched, fetched); err != nil {
+        return Leaderboard{}, time.Time{}, fmt.Errorf("cache leaderboard: %w", err)
+    }
+
+    return lb, fetched, nil
+}
+
+// ParseLeaderboard parses the JSON of a private leaderboard. AoC answers with
+// the login page instead when the session is not valid.
+func ParseLeaderboard(data []byte) (Leaderboard, error) {
+    lb := Leaderboard{}
+    if err := json.Unmarshal(data, &lb); err != nil {
+        if strings.HasPrefix(strings.TrimSpace(string(data)), "<") {
+            return lb, fmt.Errorf("leaderboard is not JSON: %w", ErrUnauthorized)
+        }
+        return lb, fmt.Errorf("parse leaderboard: %w", err)
+    }
+    return lb, nil
+}
+
+// GetLeaderboard returns the JSON of a private leaderboard of a year. Use
+// Cache.FetchLeaderboard to respect the courtesy interval.
+func GetLeaderboard(ctx context.Context, year, id string, session string) ([]byte, error) {
+    if err := ValidLeaderboardID(id); err != nil {
+        return nil, err
+    }
+    if session == "" {
+        return nil, fmt.Errorf("no session token provided: %w", ErrUnauthorized)
+    }
+
+    req, err := createReq(ctx, http.MethodGet, http.NoBody, session, year, "leaderboard", "private", "view", id + ".json")
+    if err != nil {
+        return nil, fmt.Errorf("create leaderboard request: %w", err)
+    }
+
+    status, body, err := getPage(ctx, req, fmt.Sprintf("[leaderboard %s]", id), "leaderboard")
+    if err != nil {
+        return nil, err
+    }
+    if status == http.StatusNotFound {
+        return nil, fmt.Errorf("[leaderboard %s]: %w", id, ErrNotFound)
+    }
+
+    return body, nil
+}
+
+// WriteLeaderboard writes the ranking with the stars of every member, and a
+// table with how long each member took from part 1 to part 2 of every day.
+// In the stars column, '*' means both parts of a day are solved, '+' only
+// part 1 and '.' none.
+func WriteLeaderboard(w io.Writer, lb Leaderboard) error {
+    last := lb.LastDay()
+    ranked := lb.Ranked()
+
+    tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+    fmt.Fprintln(tw, "#\tNAME\tSCORE\tSTARS\tDAYS")
+    for idx, m := range ranked {
+        days := strings.Builder{}
+        for day := 1; day <= last; day++ {
+            days.WriteByte(".+*"[min(m.Parts(day), 2)])
+        }
+        fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\n", idx + 1, m.DisplayName(), m.LocalScore, m.Stars, days.String())
+    }
+
+    if err := tw.Flush(); err != nil {
+        return err
+    }
+
+    if last == 0 {
+        return nil
+    }
+
+    fmt.Fprintln(w)
+    tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+    header := []string{"NAME"}
+    for day := 1; day <= last; day++ {
+        header = append(header, strconv.Itoa(day))
+    }
+    fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+    for _, m := range ranked {
+        row := []string{m.DisplayName()}
+        for day := 1; day <= last; day++ {
+            delta, ok := m.Delta(day)
+            if ok {
+                row = append(row, delta.String())
+            } else {
+                row = append(row, "-")
+            }
+        }
+        fmt.Fprintln(tw, strings.Join(row, "\t"))
+    }
+
+    return tw.Flush()
+}
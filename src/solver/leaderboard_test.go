@@ -0,0 +1,118 @@
+package solver
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "net/http"
+    "os"
+    "testing"
+    "time"
+)
+
+const recordedLeaderboard = `{"event":"2023","owner_id":1001,"members":{
+"1001":{"id":1001,"name":"alice","stars":5,"local_score":26,"global_score":0,"last_star_ts":1701587000,
+ "completion_day_level":{"1":{"1":{"get_star_ts":1701407000,"star_index":1},"2":{"get_star_ts":1701407300,"star_index":2}},
+  "2":{"1":{"get_star_ts":1701493500,"star_index":3},"2":{"get_star_ts":1701494100,"star_index":4}},
+  "3":{"1":{"get_star_ts":1701587000,"star_index":5}}}},
+"1002":{"id":1002,"name":null,"stars":2,"local_score":6,"global_score":0,"last_star_ts":1701420000,
+ "completion_day_level":{"1":{"1":{"get_star_ts":1701410000,"star_index":1},"2":{"get_star_ts":1701420000,"star_index":2}}}},
+"1003":{"id":1003,"name":"carol","stars":3,"local_score":26,"global_score":0,"last_star_ts":1701600000,
+ "completion_day_level":{"2":{"1":{"get_star_ts":1701493000,"star_index":1},"2":{"get_star_ts":1701493060,"star_index":2}},
+  "3":{"1":{"get_star_ts":1701600000,"star_index":3}}}}
+}}`
+
+func TestWriteLeaderboard(t *testing.T) {
+    lb, err := ParseLeaderboard([]byte(recordedLeaderboard))
+    if err != nil {
+        t.Fatalf("ParseLeaderboard failed: %v", err)
+    }
+
+    buf := bytes.Buffer{}
+    if err := WriteLeaderboard(&buf, lb); err != nil {
+        t.Fatalf("WriteLeaderboard failed: %v", err)
+    }
+
+    expected := `#  NAME                    SCORE  STARS  DAYS
+1  alice                   26     5      **+
+2  carol                   26     3      .*+
+3  (anonymous user #1002)  6      2      *..
+
+NAME                    1         2      3
+alice                   5m0s      10m0s  -
+carol                   -         1m0s   -
+(anonymous user #1002)  2h46m40s  -      -
+`
+    if buf.String() != expected {
+        t.Fatalf("WriteLeaderboard =\n%v\nwant\n%v", buf.String(), expected)
+    }
+
+    testcases := []TestCase[int, time.Duration]{
+        {1, 5 * time.Minute},
+        {2, 10 * time.Minute},
+        {3, -1},
+    }
+    alice := lb.Members["1001"]
+    for _, tc := range testcases {
+        delta, ok := alice.Delta(tc.input)
+        if ok != (tc.expected >= 0) || (ok && delta != tc.expected) {
+            t.Fatalf("Delta(%v) = %v, %v, want %v", tc.input, delta, ok, tc.expected)
+        }
+    }
+}
+
+func TestFetchLeaderboard(t *testing.T) {
+    start := time.Date(2023, time.December, 5, 6, 0, 0, 0, time.UTC)
+    clock := withClock(t, start)
+    fake := &fakeClient{status: http.StatusOK, body: recordedLeaderboard}
+    withClient(t, fake)
+
+    cache := Cache{Dir: t.TempDir()}
+    ctx := context.Background()
+
+    lb, fetched, err := cache.FetchLeaderboard(ctx, DefaultYear, "1001", "secret")
+    if err != nil || len(lb.Members) != 3 || !fetched.Equal(start) {
+        t.Fatalf("FetchLeaderboard = %v members fetched at %v, %v, want %v at %v", len(lb.Members), fetched, err, 3, start)
+    }
+    if path := fake.requests[0].URL.Path; path != "/2023/leaderboard/private/view/1001.json" {
+        t.Fatalf("FetchLeaderboard requested %v", path)
+    }
+
+    clock.now = start.Add(LeaderboardInterval - time.Second)
+    if _, fetched, err := cache.FetchLeaderboard(ctx, DefaultYear, "1001", "secret"); err != nil || len(fake.requests) != 1 || !fetched.Equal(start) {
+        t.Fatalf("FetchLeaderboard within the interval = %v at %v after %v requests, want it cached at %v", err, fetched, len(fake.requests), start)
+    }
+
+    clock.now = start.Add(LeaderboardInterval)
+    if _, fetched, err := cache.FetchLeaderboard(ctx, DefaultYear, "1001", "secret"); err != nil || len(fake.requests) != 2 || !fetched.Equal(clock.now) {
+        t.Fatalf("FetchLeaderboard after the interval = %v at %v after %v requests, want it fetched again at %v", err, fetched, len(fake.requests), clock.now)
+    }
+}
+
+func TestFetchLeaderboardLoggedOut(t *testing.T) {
+    fake := &fakeClient{status: http.StatusOK, body: "<!DOCTYPE html><html><body>Please log in</body></html>"}
+    withClient(t, fake)
+
+    cache := Cache{Dir: t.TempDir()}
+    if _, _, err := cache.FetchLeaderboard(context.Background(), DefaultYear, "1001", "expired"); !errors.Is(err, ErrUnauthorized) {
+        t.Fatalf("FetchLeaderboard with an expired session = %v, want %v", err, ErrUnauthorized)
+    }
+    if _, err := os.Stat(cache.LeaderboardPath(DefaultYear, "1001")); err == nil {
+        t.Fatalf("FetchLeaderboard cached the login page")
+    }
+}
+
+func TestFetchLeaderboardInvalidID(t *testing.T) {
+    fake := &fakeClient{status: http.StatusOK, body: "{}"}
+    withClient(t, fake)
+
+    cache := Cache{Dir: t.TempDir()}
+    for _, id := range []string{"", "../1001", "1001.json", "+1001", "01001", "abc"} {
+        if _, _, err := cache.FetchLeaderboard(context.Background(), DefaultYear, id, "secret"); err == nil {
+            t.Fatalf("FetchLeaderboard(%q) succeeded, want an error", id)
+        }
+    }
+    if len(fake.requests) != 0 {
+        t.Fatalf("FetchLeaderboard with invalid ids sent %v requests, want none", len(fake.requests))
+    }
+}
@@ -0,0 +1,153 @@
+package solver
+
+import (
+    "fmt"
+    "io"
+    "regexp"
+    "strconv"
+    "strings"
+
+    "github.com/wthys/advent-of-code-2023/grid"
+)
+
+var (
+    reInt = regexp.MustCompile(`-?[0-9]+`)
+)
+
+// Input is a puzzle input, or a part of one, offering the common ways of
+// splitting it up. Parse errors mention the line number in the whole input.
+type Input struct {
+    lines []string
+    offset int
+}
+
+// NewInput wraps the lines a Solver receives.
+func NewInput(lines []string) Input {
+    return Input{lines, 0}
+}
+
+// ReadInput reads a whole puzzle input, trimming trailing whitespace from
+// every line like ReadLines.
+func ReadInput(r io.Reader) (Input, error) {
+    lines, err := ReadLines(r)
+    if err != nil {
+        return Input{}, err
+    }
+    return NewInput(lines), nil
+}
+
+// Lines returns the lines of the input.
+func (in Input) Lines() []string {
+    return in.lines
+}
+
+// Text returns the input as a single string, without trailing blank lines.
+func (in Input) Text() string {
+    return strings.TrimRight(strings.Join(in.lines, "\n"), "\n")
+}
+
+// Offset returns the number of lines in the whole input before this one.
+func (in Input) Offset() int {
+    return in.offset
+}
+
+// Sections splits the input on blank lines, e.g. the maps of day 5 or the
+// patterns of day 13. Consecutive blank lines do not result in empty sections.
+func (in Input) Sections() []Input {
+    sections := []Input{}
+
+    start := -1
+    for idx := 0; idx <= len(in.lines); idx++ {
+        blank := idx == len(in.lines) || len(strings.TrimSpace(in.lines[idx])) == 0
+        if !blank {
+            if start < 0 {
+                start = idx
+            }
+            continue
+        }
+        if start >= 0 {
+            sections = append(sections, Input{in.lines[start:idx], in.offset + start})
+            start = -1
+        }
+    }
+
+    return sections
+}
+
+// Ints returns all integers on every line, including negative ones, e.g. the
+// card numbers of day 4 or the histories of day 9. Lines without integers
+// result in an empty slice, so the slices line up with Lines.
+func (in Input) Ints() ([][]int, error) {
+    all := make([][]int, 0, len(in.lines))
+
+    for idx, line := range in.lines {
+        ints, err := parseInts(reInt.FindAllString(line, -1))
+        if err != nil {
+            return nil, in.lineError(idx, err)
+        }
+        all = append(all, ints)
+    }
+
+    return all, nil
+}
+
+// Fields parses every whitespace separated field of every line as an
+// integer, failing on anything else.
+func (in Input) Fields() ([][]int, error) {
+    all := make([][]int, 0, len(in.lines))
+
+    for idx, line := range in.lines {
+        ints, err := parseInts(strings.Fields(line))
+        if err != nil {
+            return nil, in.lineError(idx, err)
+        }
+        all = append(all, ints)
+    }
+
+    return all, nil
+}
+
+// Tokens splits the whole input on sep, e.g. the comma separated steps of
+// day 15. Line breaks are ignored and tokens are trimmed; empty tokens are
+// left out.
+func (in Input) Tokens(sep string) []string {
+    tokens := []string{}
+    for _, token := range strings.Split(strings.Join(in.lines, ""), sep) {
+        token = strings.TrimSpace(token)
+        if token != "" {
+            tokens = append(tokens, token)
+        }
+    }
+    return tokens
+}
+
+// Grid parses the input as a single grid of characters with its top left
+// character at (0,0).
+func (in Input) Grid() (grid.Parsed[rune], error) {
+    return ParseGrid(in, grid.Parser[rune]{Mapper: grid.Runes})
+}
+
+// ParseGrid parses the input as a single grid with the given parser, e.g. to
+// convert, skip or mark some characters.
+func ParseGrid[T any](in Input, parser grid.Parser[T]) (grid.Parsed[T], error) {
+    // leading blank lines are ignored, but keep the line numbers in errors
+    // relative to the whole input
+    padded := append(make([]string, in.offset), in.lines...)
+    return parser.Parse(padded)
+}
+
+func (in Input) lineError(idx int, err error) error {
+    return fmt.Errorf("line #%v: %w", in.offset+idx+1, err)
+}
+
+func parseInts(fields []string) ([]int, error) {
+    ints := make([]int, 0, len(fields))
+    for _, field := range fields {
+        value, err := strconv.Atoi(field)
+        if err != nil {
+            return nil, err
+        }
+        ints = append(ints, value)
+    }
+    return ints, nil
+}
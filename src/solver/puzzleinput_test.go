@@ -0,0 +1,90 @@
+package solver
+
+import (
+    "reflect"
+    "strings"
+    "testing"
+
+    "github.com/wthys/advent-of-code-2023/location"
+)
+
+func TestInputSections(t *testing.T) {
+    in, err := ReadInput(strings.NewReader("seeds: 79 14\n\n\nseed-to-soil map:\n50 98 2\n52 50 48\n\nsoil-to-fertilizer map:\n0 15 37\n"))
+    if err != nil {
+        t.Fatalf("ReadInput failed: %v", err)
+    }
+
+    sections := in.Sections()
+    expected := []TestCase[int, []string]{
+        {0, []string{"seeds: 79 14"}},
+        {3, []string{"seed-to-soil map:", "50 98 2", "52 50 48"}},
+        {7, []string{"soil-to-fertilizer map:", "0 15 37"}},
+    }
+    if len(sections) != len(expected) {
+        t.Fatalf("Sections() = %v sections, want %v", len(sections), len(expected))
+    }
+    for idx, tc := range expected {
+        if sections[idx].Offset() != tc.input || !reflect.DeepEqual(sections[idx].Lines(), tc.expected) {
+            t.Fatalf("Sections()[%v] = %v at %v, want %v at %v", idx, sections[idx].Lines(), sections[idx].Offset(), tc.expected, tc.input)
+        }
+    }
+
+    ints, err := sections[1].Ints()
+    if err != nil || !reflect.DeepEqual(ints, [][]int{{}, {50, 98, 2}, {52, 50, 48}}) {
+        t.Fatalf("Ints() = %v, %v", ints, err)
+    }
+
+    _, err = sections[1].Fields()
+    if err == nil || !strings.HasPrefix(err.Error(), "line #4:") {
+        t.Fatalf("Fields() = %v, want an error on line #4", err)
+    }
+}
+
+func TestInputInts(t *testing.T) {
+    in := NewInput([]string{"0 3 6 9", "", "10 -13 16"})
+
+    ints, err := in.Ints()
+    if err != nil || !reflect.DeepEqual(ints, [][]int{{0, 3, 6, 9}, {}, {10, -13, 16}}) {
+        t.Fatalf("Ints() = %v, %v", ints, err)
+    }
+
+    fields, err := in.Fields()
+    if err != nil || !reflect.DeepEqual(fields, ints) {
+        t.Fatalf("Fields() = %v, %v, want %v", fields, err, ints)
+    }
+
+    _, err = NewInput([]string{"1", "99999999999999999999"}).Ints()
+    if err == nil || !strings.HasPrefix(err.Error(), "line #2:") {
+        t.Fatalf("Ints() = %v, want an error on line #2", err)
+    }
+}
+
+func TestInputTokens(t *testing.T) {
+    in := NewInput([]string{"rn=1,cm-,qp=3,", "cm=2,qp-", ""})
+
+    tokens := in.Tokens(",")
+    if !reflect.DeepEqual(tokens, []string{"rn=1", "cm-", "qp=3", "cm=2", "qp-"}) {
+        t.Fatalf("Tokens(,) = %v", tokens)
+    }
+
+    if text := in.Text(); text != "rn=1,cm-,qp=3,\ncm=2,qp-" {
+        t.Fatalf("Text() = %q", text)
+    }
+}
+
+func TestInputGrid(t *testing.T) {
+    in := NewInput([]string{"#.##", "..#.", "", "#...", ".#.#"})
+    patterns := in.Sections()
+
+    parsed, err := patterns[1].Grid()
+    if err != nil {
+        t.Fatalf("Grid() failed: %v", err)
+    }
+    if value, _ := parsed.Grid.Get(location.New(1, 1)); value != '#' {
+        t.Fatalf("Grid() has %q at (1,1), want '#'", value)
+    }
+
+    if _, err := in.Grid(); err == nil {
+        t.Fatalf("Grid() of two patterns succeeded, want an error")
+    }
+}
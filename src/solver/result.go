@@ -2,14 +2,29 @@ package solver
 
 import (
     "fmt"
+    "io"
+    "text/tabwriter"
     "time"
 )
 
+type Status string
+
+const (
+    StatusSolved = Status("solved")
+    StatusPartial = Status("partial")
+    StatusNotImplemented = Status("not implemented")
+    StatusError = Status("error")
+    StatusTimeout = Status("timed out")
+    StatusNoInput = Status("no input")
+)
+
 type Result struct{
     Name string
     Part1 string
     Part2 string
     Elapsed []time.Duration
+    Status Status
+    Err error
 }
 
 func (r Result) String() string {
@@ -31,3 +46,37 @@ func (r Result) String() string {
 
     return fmt.Sprintf("%v\t%v\t%v", r.Name, r.Part1, r.Part2)
 }
+
+func (r Result) StatusString() string {
+    status := r.Status
+    if status == "" {
+        status = Status(Unknown)
+    }
+
+    if r.Err != nil {
+        return fmt.Sprintf("%v: %v", status, r.Err)
+    }
+
+    return string(status)
+}
+
+// WriteTable writes the results as an aligned table with one row per day.
+func WriteTable(w io.Writer, results []Result) error {
+    tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+    fmt.Fprintln(tw, "DAY\tPART1\tPART2\tTIME1\tTIME2\tSTATUS")
+
+    for _, r := range results {
+        time1, time2 := "-", "-"
+        if len(r.Elapsed) > 0 {
+            time1 = r.Elapsed[0].String()
+        }
+        if len(r.Elapsed) > 1 {
+            time2 = r.Elapsed[1].String()
+        }
+
+        fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\n", r.Name, r.Part1, r.Part2, time1, time2, r.StatusString())
+    }
+
+    return tw.Flush()
+}
@@ -0,0 +1,68 @@
+package solver
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "io"
+    "io/fs"
+    "os"
+    "path/filepath"
+)
+
+// InputLoader opens the input for a day. The error wraps fs.ErrNotExist when
+// there is no input for that day.
+type InputLoader func(day string) (io.ReadCloser, error)
+
+// InputPath returns the path of the input file for a day inside dir.
+func InputPath(dir string, day string) string {
+    return filepath.Join(dir, fmt.Sprintf("day%s.txt", day))
+}
+
+// DirInputs creates an InputLoader reading the inputs of a year from dir.
+// Inputs of DefaultYear are read from InputPath(dir, day), those of other
+// years from a subdirectory named after the year.
+func DirInputs(dir string, year string) InputLoader {
+    if year != DefaultYear {
+        dir = filepath.Join(dir, year)
+    }
+    return func(day string) (io.ReadCloser, error) {
+        return os.Open(InputPath(dir, day))
+    }
+}
+
+// RunAll solves every registered day of a year in order, reading the input of
+// each day with load. Problems are recorded in the results instead of stopping
+// the run.
+func RunAll(ctx context.Context, year string, load InputLoader) []Result {
+    results := []Result{}
+
+    for _, s := range YearSolvers(year) {
+        res := Result{
+            Name: s.Day(),
+            Part1: Unsolved,
+            Part2: Unsolved,
+            Elapsed: nil,
+        }
+
+        file, err := load(s.Day())
+        if errors.Is(err, fs.ErrNotExist) {
+            res.Status = StatusNoInput
+            results = append(results, res)
+            continue
+        }
+        if err != nil {
+            res.Status = StatusError
+            res.Err = fmt.Errorf("failed to open input: %w", err)
+            results = append(results, res)
+            continue
+        }
+
+        // errors are recorded in the result
+        res, _ = solveFrom(ctx, s, file)
+
+        results = append(results, res)
+    }
+
+    return results
+}
@@ -0,0 +1,160 @@
+package solver
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "fmt"
+    "io"
+    "io/fs"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+)
+
+// lineSolver answers with the number of lines and the first line, or fails
+// when the first line is "fail". Part 2 can be left unimplemented.
+type lineSolver struct {
+    year string
+    day string
+    part2 bool
+}
+
+func (s lineSolver) Part1(input []string) (string, error) {
+    return Solved(len(input))
+}
+
+func (s lineSolver) Part2(input []string) (string, error) {
+    if !s.part2 {
+        return NotImplemented()
+    }
+    if len(input) > 0 && input[0] == "fail" {
+        return Error(errors.New("bad input"))
+    }
+    return Solved(input[0])
+}
+
+func (s lineSolver) Day() string {
+    return s.day
+}
+
+func (s lineSolver) Year() string {
+    return s.year
+}
+
+// stubInputs loads the inputs from memory. Days without input do not exist,
+// and loading the day in broken fails.
+func stubInputs(inputs map[string]string, broken string) InputLoader {
+    return func(day string) (io.ReadCloser, error) {
+        if day == broken {
+            return nil, fmt.Errorf("day%s.txt: permission denied", day)
+        }
+        input, ok := inputs[day]
+        if !ok {
+            return nil, fmt.Errorf("day%s.txt: %w", day, fs.ErrNotExist)
+        }
+        return io.NopCloser(strings.NewReader(input)), nil
+    }
+}
+
+// registerLineSolvers registers lineSolvers for 1915: days 1, 2 and 10 with
+// both parts, day 3 with only part 1 and day 4 with none.
+func registerLineSolvers(t *testing.T) {
+    withRegistry(t)
+    for _, day := range []string{"10", "2", "1"} {
+        Register(lineSolver{"1915", day, true})
+    }
+    Register(lineSolver{"1915", "3", false})
+    Register(yearSolver{year: "1915", day: "4"})
+}
+
+func TestRunAll(t *testing.T) {
+    registerLineSolvers(t)
+
+    inputs := stubInputs(map[string]string{"1": "a\nb\n", "3": "c\n", "4": "d\n", "10": "fail\n"}, "2")
+    results := RunAll(context.Background(), "1915", inputs)
+
+    expected := []Result{
+        {Name: "1", Part1: "3", Part2: "a", Status: StatusSolved},
+        {Name: "2", Part1: Unsolved, Part2: Unsolved, Status: StatusError},
+        {Name: "3", Part1: "2", Part2: Unimplemented, Status: StatusPartial},
+        {Name: "4", Part1: Unimplemented, Part2: Unimplemented, Status: StatusNotImplemented},
+        {Name: "10", Part1: "2", Part2: Unsolved, Status: StatusError},
+    }
+
+    if len(results) != len(expected) {
+        t.Fatalf("RunAll = %v results, want %v", len(results), len(expected))
+    }
+    for idx, res := range results {
+        exp := expected[idx]
+        if res.Name != exp.Name || res.Part1 != exp.Part1 || res.Part2 != exp.Part2 || res.Status != exp.Status {
+            t.Fatalf("RunAll result #%v = %v (%v), want %v (%v)", idx, res, res.StatusString(), exp, exp.Status)
+        }
+        if (res.Status == StatusError) != (res.Err != nil) {
+            t.Fatalf("RunAll result #%v has status %v and error %v", idx, res.Status, res.Err)
+        }
+    }
+
+    results = RunAll(context.Background(), "1915", stubInputs(map[string]string{}, ""))
+    for _, res := range results {
+        if res.Status != StatusNoInput || res.Err != nil {
+            t.Fatalf("RunAll without inputs = %v (%v), want %v", res, res.StatusString(), StatusNoInput)
+        }
+    }
+}
+
+func TestWriteTable(t *testing.T) {
+    results := []Result{
+        {Name: "1", Part1: "54239", Part2: "55343", Elapsed: []time.Duration{1500 * time.Nanosecond, 2 * time.Millisecond}, Status: StatusSolved},
+        {Name: "10", Part1: "7", Part2: Unimplemented, Status: StatusPartial},
+        {Name: "11", Part1: Unsolved, Part2: Unsolved, Status: StatusError, Err: errors.New("bad input")},
+        {Name: "12", Part1: Unsolved, Part2: Unsolved, Status: StatusNoInput},
+    }
+
+    expected := "" +
+        "DAY  PART1     PART2            TIME1  TIME2  STATUS\n" +
+        "1    54239     55343            1.5µs  2ms    solved\n" +
+        "10   7         not implemented  -      -      partial\n" +
+        "11   unsolved  unsolved         -      -      error: bad input\n" +
+        "12   unsolved  unsolved         -      -      no input\n"
+
+    out := bytes.Buffer{}
+    if err := WriteTable(&out, results); err != nil {
+        t.Fatalf("WriteTable = %v", err)
+    }
+    if out.String() != expected {
+        t.Fatalf("WriteTable =\n%v\nwant\n%v", out.String(), expected)
+    }
+}
+
+func TestDirInputs(t *testing.T) {
+    dir := t.TempDir()
+    if err := writeFile(filepath.Join(dir, "day1.txt"), []byte("2023\n")); err != nil {
+        t.Fatal(err)
+    }
+    if err := writeFile(filepath.Join(dir, "1915", "day1.txt"), []byte("1915\n")); err != nil {
+        t.Fatal(err)
+    }
+
+    testcases := []TestCase[string, string]{
+        {DefaultYear, "2023\n"},
+        {"1915", "1915\n"},
+    }
+
+    for _, tc := range testcases {
+        file, err := DirInputs(dir, tc.input)("1")
+        if err != nil {
+            t.Fatalf("DirInputs(%v) day 1 = %v, want %q", tc.input, err, tc.expected)
+        }
+        data, err := io.ReadAll(file)
+        file.Close()
+        if err != nil || string(data) != tc.expected {
+            t.Fatalf("DirInputs(%v) day 1 = %q, %v, want %q", tc.input, data, err, tc.expected)
+        }
+    }
+
+    if _, err := DirInputs(dir, "1916")("1"); !errors.Is(err, fs.ErrNotExist) {
+        t.Fatalf("DirInputs(1916) day 1 = %v, want %v", err, fs.ErrNotExist)
+    }
+}
@@ -5,6 +5,8 @@ import (
     "fmt"
     "io"
     "context"
+    "sort"
+    "strconv"
     "time"
 )
 
@@ -12,6 +14,7 @@ import (
 const (
     Unknown = "unknown"
     Unsolved = "unsolved"
+    Unimplemented = "not implemented"
     Undefined = "undefined"
     InProgress = "in progress"
 )
@@ -42,38 +45,143 @@ type Solver interface{
     Day() string
 }
 
+// YearSolver is a Solver for an event other than DefaultYear.
+type YearSolver interface{
+    Solver
+    Year() string
+}
+
+// Key identifies a puzzle by event year and day.
+type Key struct {
+    Year string
+    Day string
+}
+
 var (
-    solvers = make(map[string]Solver)
+    solvers = make(map[Key]Solver)
 )
 
 
+// YearOf returns the event year of a solver, DefaultYear unless it is a
+// YearSolver.
+func YearOf(solver Solver) string {
+    if ys, ok := solver.(YearSolver); ok {
+        return ys.Year()
+    }
+    return DefaultYear
+}
+
+func (k Key) String() string {
+    return fmt.Sprintf("%s/%s", k.Year, k.Day)
+}
+
 func Register(solver Solver) {
     if solver == nil {
         panic("puzzle: Register solver is nil")
     }
 
-    name := solver.Day()
+    key := Key{YearOf(solver), solver.Day()}
 
-    if _, dup := solvers[name]; dup {
-        panic(fmt.Errorf("puzzle: Register called twice for solver [%s]", name))
+    if _, dup := solvers[key]; dup {
+        panic(fmt.Errorf("puzzle: Register called twice for solver [%s]", key))
     }
 
-    solvers[name] = solver
+    solvers[key] = solver
 }
 
+// GetSolver returns the solver of a day of DefaultYear.
 func GetSolver(day string) (Solver, error) {
+    return GetYearSolver(DefaultYear, day)
+}
+
+// GetYearSolver returns the solver of a day of the given year.
+func GetYearSolver(year, day string) (Solver, error) {
     if day == "" {
         return nil, errors.New("empty puzzle day")
     }
+    if year == "" {
+        return nil, errors.New("empty puzzle year")
+    }
+
+    key := Key{year, day}
 
-    solver, exist := solvers[day]
+    solver, exist := solvers[key]
     if !exist {
-        return nil, fmt.Errorf("%s: %w", day, errors.New("unknown puzzle day"))
+        return nil, fmt.Errorf("%s: %w", key, errors.New("unknown puzzle day"))
     }
 
     return solver, nil
 }
 
+// Solvers returns all registered solvers of DefaultYear, ordered by day.
+func Solvers() []Solver {
+    return YearSolvers(DefaultYear)
+}
+
+// YearSolvers returns all registered solvers of a year, ordered by day.
+func YearSolvers(year string) []Solver {
+    all := []Solver{}
+    for key, solver := range solvers {
+        if key.Year == year {
+            all = append(all, solver)
+        }
+    }
+
+    sort.Slice(all, func(i, j int) bool {
+        return dayLess(all[i].Day(), all[j].Day())
+    })
+
+    return all
+}
+
+// Years returns all years with registered solvers, in order.
+func Years() []string {
+    seen := map[string]bool{}
+    years := []string{}
+    for key := range solvers {
+        if !seen[key.Year] {
+            seen[key.Year] = true
+            years = append(years, key.Year)
+        }
+    }
+
+    sort.Slice(years, func(i, j int) bool {
+        return dayLess(years[i], years[j])
+    })
+
+    return years
+}
+
+// selectSolvers returns the solvers of the given days of a year, or all its
+// registered solvers when there are none.
+func selectSolvers(year string, days []string) ([]Solver, error) {
+    if len(days) == 0 {
+        return YearSolvers(year), nil
+    }
+
+    selected := []Solver{}
+    for _, day := range days {
+        s, err := GetYearSolver(year, day)
+        if err != nil {
+            return nil, err
+        }
+        selected = append(selected, s)
+    }
+
+    return selected, nil
+}
+
+func dayLess(a, b string) bool {
+    na, erra := strconv.Atoi(a)
+    nb, errb := strconv.Atoi(b)
+    if erra != nil || errb != nil {
+        return a < b
+    }
+    return na < nb
+}
+
+// Solve reads the input and solves both parts. When solving fails, the
+// returned result still holds what is known, including the error.
 func Solve(solver Solver, input io.Reader, ctx context.Context) (Result, error) {
     res := Result{
         Name: solver.Day(),
@@ -85,55 +193,104 @@ func Solve(solver Solver, input io.Reader, ctx context.Context) (Result, error)
     lines, err := ReadLines(input)
 
     if err != nil {
-        return Result{}, fmt.Errorf("failed to read: %w", err)
+        res.Status = StatusError
+        res.Err = fmt.Errorf("failed to read: %w", err)
+        return res, res.Err
     }
 
     if err := res.AddAnswers(solver, lines, ctx); err != nil {
-        return Result{}, fmt.Errorf("failed to add answers: %w", err)
+        return res, fmt.Errorf("failed to add answers: %w", err)
     }
 
     return res, nil
 }
 
 func (r *Result) AddAnswers(s Solver, input []string, ctx context.Context) error {
-    elapsed, ok := ctx.Value("elapsed").(bool)
-    if !ok {
-        elapsed = false
+    elapsed := ElapsedFrom(ctx)
+    cs := WithContext(s)
+
+    var part1, part2 partAnswer
+    if ParallelFrom(ctx) {
+        done := make(chan partAnswer, 1)
+        go func() {
+            done <- solvePart(ctx, cs.Part2Context, input)
+        }()
+        part1 = solvePart(ctx, cs.Part1Context, input)
+        part2 = <-done
+    } else {
+        part1 = solvePart(ctx, cs.Part1Context, input)
+        if part1.err == nil || errors.Is(part1.err, ErrNotImplemented) {
+            part2 = solvePart(ctx, cs.Part2Context, input)
+        }
     }
 
-    durations := []time.Duration{}
-
-    var start time.Time
+    missing := 0
 
-    if (elapsed) {
-        start = time.Now()
-    }
-    part1, err := s.Part1(input)
-    if (elapsed) {
-        durations = append(durations, time.Since(start))
-    }
-    if err != nil && !errors.Is(err, ErrNotImplemented) {
-        return fmt.Errorf("failed to solve Part1: %w", err)
+    if errors.Is(part1.err, ErrNotImplemented) {
+        missing += 1
+    } else if part1.err != nil {
+        r.Status = failedStatus(part1.err)
+        r.Err = fmt.Errorf("failed to solve Part1: %w", part1.err)
+        return r.Err
     }
 
-    if (elapsed) {
-        start = time.Now()
+    if errors.Is(part2.err, ErrNotImplemented) {
+        missing += 1
+    } else if part2.err != nil {
+        r.Part1 = part1.answer
+        r.Status = failedStatus(part2.err)
+        r.Err = fmt.Errorf("failed to solve Part2: %w", part2.err)
+        return r.Err
     }
-    part2, err := s.Part2(input)
-    if (elapsed) {
-        durations = append(durations, time.Since(start))
+
+    r.Part1 = partResult(part1)
+    r.Part2 = partResult(part2)
+    r.Elapsed = nil
+    if elapsed {
+        r.Elapsed = []time.Duration{part1.elapsed, part2.elapsed}
     }
-    if err != nil && !errors.Is(err, ErrNotImplemented) {
-        return fmt.Errorf("failed to solve Part2: %w", err)
+
+    switch missing {
+        case 0:
+            r.Status = StatusSolved
+        case 1:
+            r.Status = StatusPartial
+        default:
+            r.Status = StatusNotImplemented
     }
 
-    if !elapsed {
-        durations = nil
+    return nil
+}
+
+type partAnswer struct {
+    answer string
+    err error
+    elapsed time.Duration
+}
+
+// partResult returns the answer of a part, or Unimplemented when the part is
+// not implemented yet.
+func partResult(part partAnswer) string {
+    if errors.Is(part.err, ErrNotImplemented) {
+        return Unimplemented
     }
+    return part.answer
+}
 
-    r.Part1 = part1
-    r.Part2 = part2
-    r.Elapsed = durations
+// answered reports whether a part holds an actual answer.
+func answered(answer string) bool {
+    return answer != "" && answer != Unsolved && answer != Unimplemented
+}
 
-    return nil
+func solvePart(ctx context.Context, part func(context.Context, []string) (string, error), input []string) partAnswer {
+    start := time.Now()
+    answer, err := part(ctx, input)
+    return partAnswer{answer, err, time.Since(start)}
+}
+
+func failedStatus(err error) Status {
+    if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+        return StatusTimeout
+    }
+    return StatusError
 }
@@ -0,0 +1,145 @@
+package solver
+
+import (
+    "context"
+    "testing"
+)
+
+type yearSolver struct {
+    unimplementedSolver
+    year string
+    day string
+}
+
+func (s yearSolver) Day() string {
+    return s.day
+}
+
+func (s yearSolver) Year() string {
+    return s.year
+}
+
+type daySolver struct {
+    unimplementedSolver
+    day string
+}
+
+func (s daySolver) Day() string {
+    return s.day
+}
+
+type halfSolver struct {
+    unimplementedSolver
+}
+
+func (s halfSolver) Part1(input []string) (string, error) {
+    return Solved(len(input))
+}
+
+func TestAddAnswersNotImplemented(t *testing.T) {
+    testcases := []TestCase[Solver, Result]{
+        {halfSolver{}, Result{Part1: "2", Part2: Unimplemented, Status: StatusPartial}},
+        {unimplementedSolver{}, Result{Part1: Unimplemented, Part2: Unimplemented, Status: StatusNotImplemented}},
+    }
+
+    for _, tc := range testcases {
+        res := Result{}
+        if err := res.AddAnswers(tc.input, []string{"a", "b"}, context.Background()); err != nil {
+            t.Fatalf("AddAnswers = %v, want %v", err, nil)
+        }
+        if res.Part1 != tc.expected.Part1 || res.Part2 != tc.expected.Part2 || res.Status != tc.expected.Status {
+            t.Fatalf("AddAnswers = %v (%v), want %v (%v)", res, res.Status, tc.expected, tc.expected.Status)
+        }
+    }
+
+    recorded := Answers{}.Record(Result{Part1: "2", Part2: Unimplemented, Status: StatusPartial})
+    if recorded.Part1 != "2" || recorded.Part2 != "" {
+        t.Fatalf("Record = %+v, want only part 1", recorded)
+    }
+}
+
+type yearContextSolver struct {
+    countingSolver
+    year string
+}
+
+func (s yearContextSolver) Year() string {
+    return s.year
+}
+
+func TestRegisterContextYear(t *testing.T) {
+    withRegistry(t)
+
+    RegisterContext(yearContextSolver{year: "1915"})
+    RegisterContext(countingSolver{})
+
+    if s, err := GetYearSolver("1915", "0"); err != nil || YearOf(s) != "1915" {
+        t.Fatalf("GetYearSolver(1915, 0) = %v, %v, want the context solver of 1915", s, err)
+    }
+    if s, err := GetYearSolver(DefaultYear, "0"); err != nil || YearOf(s) != DefaultYear {
+        t.Fatalf("GetYearSolver(%v, 0) = %v, %v, want the context solver of %v", DefaultYear, s, err, DefaultYear)
+    }
+}
+
+// withRegistry gives a test a fresh copy of the registry, restoring the
+// original afterwards.
+func withRegistry(t *testing.T) {
+    previous := solvers
+    solvers = make(map[Key]Solver)
+    for key, s := range previous {
+        solvers[key] = s
+    }
+    t.Cleanup(func() {
+        solvers = previous
+    })
+}
+
+func TestRegisterYears(t *testing.T) {
+    withRegistry(t)
+
+    Register(daySolver{day: "101"})
+    Register(yearSolver{year: "1915", day: "101"})
+    Register(yearSolver{year: "1915", day: "102"})
+
+    testcases := []TestCase[Key, bool]{
+        {Key{DefaultYear, "101"}, true},
+        {Key{"1915", "101"}, true},
+        {Key{"1915", "102"}, true},
+        {Key{DefaultYear, "102"}, false},
+        {Key{"1916", "101"}, false},
+    }
+
+    for _, tc := range testcases {
+        s, err := GetYearSolver(tc.input.Year, tc.input.Day)
+        if (err == nil) != tc.expected {
+            t.Fatalf("GetYearSolver(%v) = %v, want found %v", tc.input, err, tc.expected)
+        }
+        if err == nil && (YearOf(s) != tc.input.Year || s.Day() != tc.input.Day) {
+            t.Fatalf("GetYearSolver(%v) returned the solver for %v/%v", tc.input, YearOf(s), s.Day())
+        }
+    }
+
+    if s, err := GetSolver("101"); err != nil || YearOf(s) != DefaultYear {
+        t.Fatalf("GetSolver(101) = %v, %v, want the %v solver", s, err, DefaultYear)
+    }
+
+    days := []string{}
+    for _, s := range YearSolvers("1915") {
+        days = append(days, s.Day())
+    }
+    if len(days) != 2 || days[0] != "101" || days[1] != "102" {
+        t.Fatalf("YearSolvers(1915) = %v, want [101 102]", days)
+    }
+
+    years := Years()
+    if len(years) != 2 || years[0] != "1915" || years[1] != DefaultYear {
+        t.Fatalf("Years() = %v, want [1915 %v]", years, DefaultYear)
+    }
+
+    defer func() {
+        if recover() == nil {
+            t.Fatalf("registering 1915/101 twice did not panic")
+        }
+    }()
+    Register(yearSolver{year: "1915", day: "101"})
+}
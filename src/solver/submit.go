@@ -0,0 +1,290 @@
+package solver
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "html"
+    "io"
+    "io/fs"
+    "net/http"
+    "net/url"
+    "os"
+    "path/filepath"
+    "regexp"
+    "strconv"
+    "strings"
+    "time"
+
+    log "github.com/obalunenko/logger"
+)
+
+type Outcome string
+
+const (
+    OutcomeCorrect = Outcome("correct")
+    OutcomeTooHigh = Outcome("too high")
+    OutcomeTooLow = Outcome("too low")
+    OutcomeWrong = Outcome("wrong")
+    OutcomeWait = Outcome("wait")
+    OutcomeAlreadySolved = Outcome("already solved")
+    OutcomeUnknown = Outcome("unknown")
+)
+
+var (
+    // ErrAlreadySubmitted returns when the same answer was submitted before.
+    ErrAlreadySubmitted = errors.New("answer already submitted")
+    // ErrOutOfBounds returns when an answer is outside the bounds given by
+    // earlier too high/too low feedback.
+    ErrOutOfBounds = errors.New("answer out of known bounds")
+    // ErrAlreadySolved returns when the part was already solved.
+    ErrAlreadySolved = errors.New("part already solved")
+    // ErrTooSoon returns when AoC asked to wait before submitting again.
+    ErrTooSoon = errors.New("submitting too soon")
+
+    reArticle = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+    reTag = regexp.MustCompile(`<[^>]*>`)
+    reLeftToWait = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+    rePleaseWait = regexp.MustCompile(`[Pp]lease wait (one|\d+) minutes?`)
+)
+
+// Feedback is what AoC replied to a submitted answer.
+type Feedback struct {
+    Outcome Outcome
+    Wait time.Duration
+    Message string
+}
+
+// Guess is an answer that was submitted.
+type Guess struct {
+    Part int `json:"part"`
+    Answer string `json:"answer"`
+    Outcome Outcome `json:"outcome"`
+    Time time.Time `json:"time"`
+}
+
+// Submissions keeps track of everything submitted for a day.
+type Submissions struct {
+    Guesses []Guess `json:"guesses"`
+    WaitUntil time.Time `json:"wait_until,omitempty"`
+}
+
+// ParseFeedback interprets the HTML page AoC returns after submitting.
+func ParseFeedback(page string) Feedback {
+    message := page
+    if match := reArticle.FindStringSubmatch(page); match != nil {
+        message = match[1]
+    }
+    message = strings.Join(strings.Fields(html.UnescapeString(reTag.ReplaceAllString(message, ""))), " ")
+
+    feedback := Feedback{OutcomeUnknown, 0, message}
+
+    switch {
+        case strings.Contains(message, "That's the right answer"):
+            feedback.Outcome = OutcomeCorrect
+        case strings.Contains(message, "You gave an answer too recently"):
+            feedback.Outcome = OutcomeWait
+        case strings.Contains(message, "Did you already complete it"):
+            feedback.Outcome = OutcomeAlreadySolved
+        case strings.Contains(message, "your answer is too high"):
+            feedback.Outcome = OutcomeTooHigh
+        case strings.Contains(message, "your answer is too low"):
+            feedback.Outcome = OutcomeTooLow
+        case strings.Contains(message, "That's not the right answer"):
+            feedback.Outcome = OutcomeWrong
+    }
+
+    if match := reLeftToWait.FindStringSubmatch(message); match != nil {
+        minutes, _ := strconv.Atoi(match[1])
+        seconds, _ := strconv.Atoi(match[2])
+        feedback.Wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+    } else if match := rePleaseWait.FindStringSubmatch(message); match != nil {
+        minutes, err := strconv.Atoi(match[1])
+        if err != nil {
+            minutes = 1
+        }
+        feedback.Wait = time.Duration(minutes) * time.Minute
+    }
+
+    return feedback
+}
+
+// Check refuses answers that are known to be wrong without asking AoC: the
+// part was already solved, the same answer was submitted before, the answer
+// lies outside the bounds of earlier too high/too low feedback, or AoC asked
+// to wait until after now.
+func (s Submissions) Check(part int, answer string, now time.Time) error {
+    if now.Before(s.WaitUntil) {
+        return fmt.Errorf("%w: wait %v", ErrTooSoon, s.WaitUntil.Sub(now).Round(time.Second))
+    }
+
+    value, numeric := strconv.Atoi(answer)
+
+    for _, guess := range s.Guesses {
+        if guess.Part != part {
+            continue
+        }
+
+        if guess.Outcome == OutcomeCorrect {
+            return fmt.Errorf("%w with %q", ErrAlreadySolved, guess.Answer)
+        }
+        if guess.Answer == answer {
+            return fmt.Errorf("%w: %q was %v", ErrAlreadySubmitted, answer, guess.Outcome)
+        }
+
+        bound, err := strconv.Atoi(guess.Answer)
+        if numeric != nil || err != nil {
+            continue
+        }
+        if guess.Outcome == OutcomeTooHigh && value >= bound {
+            return fmt.Errorf("%w: %v is too high, %v already was", ErrOutOfBounds, value, bound)
+        }
+        if guess.Outcome == OutcomeTooLow && value <= bound {
+            return fmt.Errorf("%w: %v is too low, %v already was", ErrOutOfBounds, value, bound)
+        }
+    }
+
+    return nil
+}
+
+// Record adds the feedback on a submitted answer.
+func (s Submissions) Record(part int, answer string, feedback Feedback, now time.Time) Submissions {
+    if feedback.Wait > 0 {
+        s.WaitUntil = now.Add(feedback.Wait)
+    }
+
+    switch feedback.Outcome {
+        case OutcomeCorrect, OutcomeTooHigh, OutcomeTooLow, OutcomeWrong:
+            s.Guesses = append(s.Guesses, Guess{part, answer, feedback.Outcome, now})
+    }
+
+    return s
+}
+
+// SubmissionsPath returns where the submissions for a year and day are stored.
+func (c Cache) SubmissionsPath(year, day string) string {
+    return filepath.Join(c.DayDir(year, day), "submissions.json")
+}
+
+// ReadSubmissions returns what was submitted for a year and day so far.
+func (c Cache) ReadSubmissions(year, day string) (Submissions, error) {
+    submissions := Submissions{}
+
+    data, err := os.ReadFile(c.SubmissionsPath(year, day))
+    if errors.Is(err, fs.ErrNotExist) {
+        return submissions, nil
+    }
+    if err != nil {
+        return submissions, err
+    }
+
+    if err := json.Unmarshal(data, &submissions); err != nil {
+        return submissions, fmt.Errorf("parse %s: %w", c.SubmissionsPath(year, day), err)
+    }
+
+    return submissions, nil
+}
+
+// WriteSubmissions stores what was submitted for a year and day.
+func (c Cache) WriteSubmissions(year, day string, submissions Submissions) error {
+    data, err := json.MarshalIndent(submissions, "", "  ")
+    if err != nil {
+        return err
+    }
+    return writeFile(c.SubmissionsPath(year, day), append(data, '\n'))
+}
+
+// Submit posts the answer for a part of a day of a year, unless earlier submissions
+// recorded in the cache show it cannot be right. The feedback is recorded
+// as well.
+func Submit(ctx context.Context, cache Cache, year, day string, part int, answer string, session string) (Feedback, error) {
+    if part != 1 && part != 2 {
+        return Feedback{}, fmt.Errorf("invalid part %d", part)
+    }
+    if !answered(answer) {
+        return Feedback{}, fmt.Errorf("no answer to submit")
+    }
+    if session == "" {
+        return Feedback{}, fmt.Errorf("no session token provided: %w", ErrUnauthorized)
+    }
+
+    submissions, err := cache.ReadSubmissions(year, day)
+    if err != nil {
+        return Feedback{}, fmt.Errorf("read submissions: %w", err)
+    }
+
+    if err := submissions.Check(part, answer, SystemClock.Now()); err != nil {
+        return Feedback{}, err
+    }
+
+    form := url.Values{}
+    form.Set("level", strconv.Itoa(part))
+    form.Set("answer", answer)
+
+    req, err := createReq(ctx, http.MethodPost, strings.NewReader(form.Encode()), session, year, "day", day, "answer")
+    if err != nil {
+        return Feedback{}, fmt.Errorf("create answer request: %w", err)
+    }
+    req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+    resp, err := Client.Do(req)
+    if err != nil {
+        return Feedback{}, fmt.Errorf("send request: %w", err)
+    }
+
+    defer func() {
+        if err = resp.Body.Close(); err != nil {
+            log.WithError(ctx, err).Error("Failed to close body")
+        }
+    }()
+
+    body, err := io.ReadAll(resp.Body)
+    if err != nil {
+        return Feedback{}, fmt.Errorf("read response body: %w", err)
+    }
+
+    if sessionRejected(req, body) {
+        return Feedback{}, fmt.Errorf("[%s] session not accepted: %w", day, ErrUnauthorized)
+    }
+
+    switch resp.StatusCode {
+        case http.StatusOK:
+        case http.StatusBadRequest, http.StatusUnauthorized:
+            return Feedback{}, ErrUnauthorized
+        case http.StatusNotFound:
+            return Feedback{}, fmt.Errorf("[%s]: %w", day, ErrNotFound)
+        default:
+            return Feedback{}, fmt.Errorf("[%s] failed to submit answer[%s]", day, resp.Status)
+    }
+
+    feedback := ParseFeedback(string(body))
+
+    submissions = submissions.Record(part, answer, feedback, SystemClock.Now())
+    if err := cache.WriteSubmissions(year, day, submissions); err != nil {
+        return feedback, fmt.Errorf("record submission: %w", err)
+    }
+
+    if feedback.Outcome == OutcomeCorrect {
+        if err := recordCorrect(cache, year, day, part, answer); err != nil {
+            return feedback, fmt.Errorf("record answer: %w", err)
+        }
+    }
+
+    return feedback, nil
+}
+
+func recordCorrect(cache Cache, year, day string, part int, answer string) error {
+    known, err := cache.ReadAnswers(year, day)
+    if err != nil {
+        return err
+    }
+
+    if part == 1 {
+        known.Part1 = answer
+    } else {
+        known.Part2 = answer
+    }
+
+    return cache.WriteAnswers(year, day, known)
+}
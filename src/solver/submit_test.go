@@ -0,0 +1,152 @@
+package solver
+
+import (
+    "context"
+    "errors"
+    "net/http"
+    "testing"
+    "time"
+)
+
+const (
+    pageCorrect = `<main><article><p>That's the right answer! You are <em>one gold star</em> closer to restoring snow operations. <a href="/2023/day/5#part2">[Continue to Part Two]</a></p></article></main>`
+    pageTooHigh = `<main><article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data; there are also some general tips on the <a href="/2023/about">about page</a>. Please wait one minute before trying again. <a href="/2023/day/5">[Return to Day 5]</a></p></article></main>`
+    pageTooLow = `<main><article><p>That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again. <a href="/2023/day/5">[Return to Day 5]</a></p></article></main>`
+    pageWrong = `<main><article><p>That's not the right answer.  If you're stuck, make sure you're using the full input data. Please wait one minute before trying again.</p></article></main>`
+    pageWait = `<main><article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 4m 35s left to wait. <a href="/2023/day/5">[Return to Day 5]</a></p></article></main>`
+    pageSolved = `<main><article><p>You don't seem to be solving the right level.  Did you already complete it? <a href="/2023/day/5">[Return to Day 5]</a></p></article></main>`
+)
+
+func TestParseFeedback(t *testing.T) {
+    testcases := []struct {
+        page string
+        expected Feedback
+    }{
+        {pageCorrect, Feedback{Outcome: OutcomeCorrect}},
+        {pageTooHigh, Feedback{Outcome: OutcomeTooHigh, Wait: time.Minute}},
+        {pageTooLow, Feedback{Outcome: OutcomeTooLow, Wait: 5 * time.Minute}},
+        {pageWrong, Feedback{Outcome: OutcomeWrong, Wait: time.Minute}},
+        {pageWait, Feedback{Outcome: OutcomeWait, Wait: 4*time.Minute + 35*time.Second}},
+        {pageSolved, Feedback{Outcome: OutcomeAlreadySolved}},
+        {"<html>something else</html>", Feedback{Outcome: OutcomeUnknown}},
+    }
+
+    for _, tc := range testcases {
+        actual := ParseFeedback(tc.page)
+        if actual.Outcome != tc.expected.Outcome || actual.Wait != tc.expected.Wait {
+            t.Fatalf("ParseFeedback(%q) = %v/%v, want %v/%v", actual.Message, actual.Outcome, actual.Wait, tc.expected.Outcome, tc.expected.Wait)
+        }
+    }
+}
+
+func TestSubmissionsCheck(t *testing.T) {
+    now := time.Date(2023, 12, 5, 6, 0, 0, 0, time.UTC)
+    submissions := Submissions{}
+    submissions = submissions.Record(1, "100", Feedback{Outcome: OutcomeTooHigh}, now)
+    submissions = submissions.Record(1, "20", Feedback{Outcome: OutcomeTooLow}, now)
+    submissions = submissions.Record(1, "50", Feedback{Outcome: OutcomeWrong}, now)
+    submissions = submissions.Record(1, "60", Feedback{Outcome: OutcomeWait, Wait: time.Minute}, now)
+
+    testcases := []struct {
+        part int
+        answer string
+        expected error
+    }{
+        {1, "50", ErrAlreadySubmitted},
+        {1, "100", ErrAlreadySubmitted},
+        {1, "101", ErrOutOfBounds},
+        {1, "19", ErrOutOfBounds},
+        {1, "60", nil},
+        {2, "100", nil},
+    }
+
+    if err := submissions.Check(1, "60", now.Add(30*time.Second)); !errors.Is(err, ErrTooSoon) {
+        t.Fatalf("Check(1, 60) before the wait = %v, want %v", err, ErrTooSoon)
+    }
+
+    later := now.Add(2 * time.Minute)
+    for _, tc := range testcases {
+        err := submissions.Check(tc.part, tc.answer, later)
+        if !errors.Is(err, tc.expected) || (err == nil) != (tc.expected == nil) {
+            t.Fatalf("Check(%v, %v) = %v, want %v", tc.part, tc.answer, err, tc.expected)
+        }
+    }
+
+    submissions = submissions.Record(1, "42", Feedback{Outcome: OutcomeCorrect}, later)
+    if err := submissions.Check(1, "43", later); !errors.Is(err, ErrAlreadySolved) {
+        t.Fatalf("Check(1, 43) after solving = %v, want %v", err, ErrAlreadySolved)
+    }
+}
+
+func TestSubmit(t *testing.T) {
+    fake := &fakeClient{status: http.StatusOK, body: pageTooLow}
+    withClient(t, fake)
+
+    cache := Cache{Dir: t.TempDir()}
+    ctx := context.Background()
+
+    feedback, err := Submit(ctx, cache, DefaultYear, "5", 1, "20", "secret")
+    if err != nil || feedback.Outcome != OutcomeTooLow {
+        t.Fatalf("Submit(5, 1, 20) = %v, %v, want %v", feedback.Outcome, err, OutcomeTooLow)
+    }
+
+    req := fake.requests[0]
+    if req.Method != http.MethodPost || req.URL.Path != "/2023/day/5/answer" {
+        t.Fatalf("Submit sent %v %v, want POST /2023/day/5/answer", req.Method, req.URL.Path)
+    }
+
+    if _, err := Submit(ctx, cache, DefaultYear, "5", 1, "20", "secret"); err == nil || len(fake.requests) != 1 {
+        t.Fatalf("resubmitting a wrong answer = %v after %v requests, want an error without a request", err, len(fake.requests))
+    }
+
+    submissions, err := cache.ReadSubmissions(DefaultYear, "5")
+    if err != nil || len(submissions.Guesses) != 1 || submissions.WaitUntil.IsZero() {
+        t.Fatalf("ReadSubmissions(5) = %+v, %v, want one guess and a wait", submissions, err)
+    }
+}
+
+func TestSubmitWaits(t *testing.T) {
+    start := time.Date(2023, time.December, 5, 6, 0, 0, 0, time.UTC)
+    clock := withClock(t, start)
+    fake := &fakeClient{status: http.StatusOK, body: pageTooLow}
+    withClient(t, fake)
+
+    cache := Cache{Dir: t.TempDir()}
+    ctx := context.Background()
+
+    if _, err := Submit(ctx, cache, DefaultYear, "5", 1, "20", "secret"); err != nil {
+        t.Fatalf("Submit(5, 1, 20) = %v", err)
+    }
+
+    fake.body = pageCorrect
+    clock.now = start.Add(5*time.Minute - time.Second)
+    if _, err := Submit(ctx, cache, DefaultYear, "5", 1, "25", "secret"); !errors.Is(err, ErrTooSoon) || len(fake.requests) != 1 {
+        t.Fatalf("Submit(5, 1, 25) at %v = %v after %v requests, want %v without a request", clock.now, err, len(fake.requests), ErrTooSoon)
+    }
+
+    clock.now = start.Add(5 * time.Minute)
+    feedback, err := Submit(ctx, cache, DefaultYear, "5", 1, "25", "secret")
+    if err != nil || feedback.Outcome != OutcomeCorrect || len(fake.requests) != 2 {
+        t.Fatalf("Submit(5, 1, 25) at %v = %v, %v after %v requests, want %v", clock.now, feedback.Outcome, err, len(fake.requests), OutcomeCorrect)
+    }
+
+    submissions, err := cache.ReadSubmissions(DefaultYear, "5")
+    if err != nil || len(submissions.Guesses) != 2 || !submissions.Guesses[0].Time.Equal(start) || !submissions.Guesses[1].Time.Equal(clock.now) {
+        t.Fatalf("ReadSubmissions(5) = %+v, %v, want guesses at %v and %v", submissions, err, start, clock.now)
+    }
+}
+
+func TestSubmitLoggedOut(t *testing.T) {
+    fake := &fakeClient{status: http.StatusOK, body: "<main><p>Please log in to submit an answer.</p></main>"}
+    withClient(t, fake)
+
+    cache := Cache{Dir: t.TempDir()}
+    if _, err := Submit(context.Background(), cache, DefaultYear, "5", 1, "20", "expired"); !errors.Is(err, ErrUnauthorized) {
+        t.Fatalf("Submit with an expired session = %v, want %v", err, ErrUnauthorized)
+    }
+
+    submissions, err := cache.ReadSubmissions(DefaultYear, "5")
+    if err != nil || len(submissions.Guesses) != 0 {
+        t.Fatalf("ReadSubmissions(5) = %+v, %v, want nothing recorded", submissions, err)
+    }
+}
@@ -0,0 +1,165 @@
+package solver
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "io/fs"
+    "os"
+    "path/filepath"
+)
+
+// Answers holds the known-good answers of a day.
+type Answers struct {
+    Part1 string `json:"part1,omitempty"`
+    Part2 string `json:"part2,omitempty"`
+}
+
+// Mismatch describes a part whose answer differs from the known-good one.
+type Mismatch struct {
+    Part int
+    Want string
+    Got string
+}
+
+// Verification is the outcome of verifying a single day.
+type Verification struct {
+    Result Result
+    Known Answers
+    Mismatches []Mismatch
+}
+
+func (m Mismatch) String() string {
+    return fmt.Sprintf("part %d: want %q, got %q", m.Part, m.Want, m.Got)
+}
+
+// AnswersPath returns where the known-good answers for a year and day are
+// stored.
+func (c Cache) AnswersPath(year, day string) string {
+    return filepath.Join(c.DayDir(year, day), "answers.json")
+}
+
+// ReadAnswers returns the known-good answers for a year and day. Days without
+// recorded answers have empty Answers.
+func (c Cache) ReadAnswers(year, day string) (Answers, error) {
+    answers := Answers{}
+
+    data, err := os.ReadFile(c.AnswersPath(year, day))
+    if errors.Is(err, fs.ErrNotExist) {
+        return answers, nil
+    }
+    if err != nil {
+        return answers, err
+    }
+
+    if err := json.Unmarshal(data, &answers); err != nil {
+        return answers, fmt.Errorf("parse %s: %w", c.AnswersPath(year, day), err)
+    }
+
+    return answers, nil
+}
+
+// WriteAnswers stores the known-good answers for a year and day.
+func (c Cache) WriteAnswers(year, day string, answers Answers) error {
+    data, err := json.MarshalIndent(answers, "", "  ")
+    if err != nil {
+        return err
+    }
+    return writeFile(c.AnswersPath(year, day), append(data, '\n'))
+}
+
+// Check compares the answers in a result with the known-good answers. Parts
+// without a known answer are not checked.
+func (a Answers) Check(res Result) []Mismatch {
+    mismatches := []Mismatch{}
+
+    if a.Part1 != "" && a.Part1 != res.Part1 {
+        mismatches = append(mismatches, Mismatch{1, a.Part1, res.Part1})
+    }
+    if a.Part2 != "" && a.Part2 != res.Part2 {
+        mismatches = append(mismatches, Mismatch{2, a.Part2, res.Part2})
+    }
+
+    return mismatches
+}
+
+// Record returns the known answers updated with the solved parts of a result.
+func (a Answers) Record(res Result) Answers {
+    if res.Status == StatusError || res.Status == StatusTimeout {
+        return a
+    }
+
+    if answered(res.Part1) {
+        a.Part1 = res.Part1
+    }
+    if answered(res.Part2) {
+        a.Part2 = res.Part2
+    }
+
+    return a
+}
+
+// VerifyAll solves the given days of a year, or all its registered days when
+// there are none, and compares their answers with the known-good answers in the cache.
+// With record set, the current answers are stored as known-good instead.
+// Days without input are skipped.
+func VerifyAll(ctx context.Context, year string, load InputLoader, cache Cache, days []string, record bool) ([]Verification, error) {
+    selected, err := selectSolvers(year, days)
+    if err != nil {
+        return nil, err
+    }
+
+    verifications := []Verification{}
+    for _, s := range selected {
+        file, err := load(s.Day())
+        if errors.Is(err, fs.ErrNotExist) {
+            continue
+        }
+        if err != nil {
+            return nil, fmt.Errorf("[%s] open input: %w", s.Day(), err)
+        }
+
+        // errors are recorded in the result
+        res, _ := solveFrom(ctx, s, file)
+
+        known, err := cache.ReadAnswers(year, s.Day())
+        if err != nil {
+            return nil, fmt.Errorf("[%s] read answers: %w", s.Day(), err)
+        }
+
+        if record {
+            known = known.Record(res)
+            if err := cache.WriteAnswers(year, s.Day(), known); err != nil {
+                return nil, fmt.Errorf("[%s] record answers: %w", s.Day(), err)
+            }
+        }
+
+        verifications = append(verifications, Verification{res, known, known.Check(res)})
+    }
+
+    return verifications, nil
+}
+
+func solveFrom(ctx context.Context, s Solver, input io.ReadCloser) (Result, error) {
+    defer input.Close()
+
+    res := Result{
+        Name: s.Day(),
+        Part1: Unsolved,
+        Part2: Unsolved,
+        Elapsed: nil,
+    }
+
+    lines, err := ReadLines(input)
+    if err != nil {
+        res.Status = StatusError
+        res.Err = fmt.Errorf("failed to read: %w", err)
+        return res, res.Err
+    }
+
+    err = res.AddAnswers(s, lines, ctx)
+
+    return res, err
+}
@@ -0,0 +1,101 @@
+package solver
+
+import (
+    "context"
+    "errors"
+    "io/fs"
+    "os"
+    "testing"
+)
+
+func TestAnswersCheck(t *testing.T) {
+    known := Answers{Part1: "42", Part2: ""}
+
+    if mismatches := known.Check(Result{Part1: "42", Part2: "7"}); len(mismatches) != 0 {
+        t.Fatalf("Check = %v, want no mismatches", mismatches)
+    }
+
+    mismatches := known.Check(Result{Part1: "41", Part2: "7"})
+    if len(mismatches) != 1 || mismatches[0] != (Mismatch{1, "42", "41"}) {
+        t.Fatalf("Check = %v, want a mismatch for part 1", mismatches)
+    }
+}
+
+func TestAnswersRecord(t *testing.T) {
+    known := Answers{Part1: "42", Part2: "7"}
+
+    recorded := known.Record(Result{Part1: "43", Part2: Unsolved, Status: StatusPartial})
+    if recorded != (Answers{"43", "7"}) {
+        t.Fatalf("Record = %v, want %v", recorded, Answers{"43", "7"})
+    }
+
+    recorded = known.Record(Result{Part1: "1", Part2: "2", Status: StatusError})
+    if recorded != known {
+        t.Fatalf("Record of a failed result = %v, want %v", recorded, known)
+    }
+}
+
+func TestAnswersStore(t *testing.T) {
+    cache := Cache{Dir: t.TempDir()}
+
+    answers, err := cache.ReadAnswers(DefaultYear, "3")
+    if err != nil || answers != (Answers{}) {
+        t.Fatalf("ReadAnswers(3) = %v, %v, want no answers", answers, err)
+    }
+
+    want := Answers{"4361", "467835"}
+    if err := cache.WriteAnswers(DefaultYear, "3", want); err != nil {
+        t.Fatalf("WriteAnswers(3) = %v", err)
+    }
+
+    answers, err = cache.ReadAnswers(DefaultYear, "3")
+    if err != nil || answers != want {
+        t.Fatalf("ReadAnswers(3) = %v, %v, want %v", answers, err, want)
+    }
+}
+
+func TestVerifyAll(t *testing.T) {
+    registerLineSolvers(t)
+
+    cache := Cache{Dir: t.TempDir()}
+    if err := cache.WriteAnswers("1915", "1", Answers{"3", "b"}); err != nil {
+        t.Fatalf("WriteAnswers(1) = %v", err)
+    }
+
+    inputs := stubInputs(map[string]string{"1": "a\nb\n", "3": "c\n"}, "")
+    ctx := context.Background()
+
+    verifications, err := VerifyAll(ctx, "1915", inputs, cache, nil, false)
+    if err != nil || len(verifications) != 2 {
+        t.Fatalf("VerifyAll = %v verifications, %v, want one for days 1 and 3", len(verifications), err)
+    }
+    if v := verifications[0]; v.Result.Name != "1" || len(v.Mismatches) != 1 || v.Mismatches[0] != (Mismatch{2, "b", "a"}) {
+        t.Fatalf("VerifyAll day %v = %v, want a mismatch for part 2", v.Result.Name, v.Mismatches)
+    }
+    if v := verifications[1]; v.Result.Name != "3" || len(v.Mismatches) != 0 {
+        t.Fatalf("VerifyAll day %v = %v, want no mismatches without known answers", v.Result.Name, v.Mismatches)
+    }
+    if _, err := os.Stat(cache.AnswersPath("1915", "3")); !errors.Is(err, fs.ErrNotExist) {
+        t.Fatalf("VerifyAll without record wrote answers for day 3: %v", err)
+    }
+
+    if _, err := VerifyAll(ctx, "1915", inputs, cache, []string{"1", "3"}, true); err != nil {
+        t.Fatalf("VerifyAll(record) = %v", err)
+    }
+
+    expected := map[string]Answers{"1": {"3", "a"}, "3": {"2", ""}}
+    for day, want := range expected {
+        answers, err := cache.ReadAnswers("1915", day)
+        if err != nil || answers != want {
+            t.Fatalf("VerifyAll(record) stored %v, %v for day %v, want %v", answers, err, day, want)
+        }
+    }
+    if _, err := os.Stat(cache.AnswersPath("1915", "2")); !errors.Is(err, fs.ErrNotExist) {
+        t.Fatalf("VerifyAll(record) wrote answers for day 2 without input: %v", err)
+    }
+
+    verifications, err = VerifyAll(ctx, "1915", inputs, cache, nil, false)
+    if err != nil || len(verifications[0].Mismatches) != 0 {
+        t.Fatalf("VerifyAll after record = %v, %v, want no mismatches", verifications[0].Mismatches, err)
+    }
+}
@@ -0,0 +1,85 @@
+package solver
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "math/rand"
+    "strconv"
+    "time"
+)
+
+// WaitOptions determine how Cache.WaitForInput waits for a puzzle to unlock.
+type WaitOptions struct {
+    // Tick is how often Countdown is called while waiting, and how long to
+    // pause before asking AoC again when it does not consider the puzzle
+    // unlocked yet. Defaults to the Tick of DefaultWaitOptions.
+    Tick time.Duration
+    // Jitter is the most to wait after the unlock, so not everyone asks at
+    // the very same moment.
+    Jitter time.Duration
+    // Attempts is how often to ask for the input after the unlock.
+    Attempts int
+    // Countdown, when set, is called with the time left until the unlock.
+    Countdown func(left time.Duration)
+}
+
+// DefaultWaitOptions ticks every second and asks within two seconds after the
+// unlock.
+var DefaultWaitOptions = WaitOptions{Tick: time.Second, Jitter: 2 * time.Second, Attempts: 5}
+
+// NextUnlock returns the year and day of the first puzzle to unlock after now.
+func NextUnlock(now time.Time) (string, string) {
+    now = now.In(release)
+    year, day := now.Year(), 1
+    if now.Month() == time.December {
+        day = now.Day() + 1
+    }
+    if day > 25 {
+        year, day = year + 1, 1
+    }
+    return strconv.Itoa(year), strconv.Itoa(day)
+}
+
+// WaitForInput waits until the puzzle of a year and day unlocks, and then
+// fetches its input like FetchInput. It keeps asking for a while when AoC
+// does not consider the puzzle unlocked yet right at the unlock time.
+func (c Cache) WaitForInput(ctx context.Context, year, day string, session string, opts WaitOptions) ([]byte, error) {
+    if session == "" {
+        return nil, fmt.Errorf("no session token provided: %w", ErrUnauthorized)
+    }
+
+    unlock, err := UnlockTime(year, day)
+    if err != nil {
+        return nil, err
+    }
+
+    if opts.Tick <= 0 {
+        opts.Tick = DefaultWaitOptions.Tick
+    }
+
+    for left := unlock.Sub(SystemClock.Now()); left > 0; left = unlock.Sub(SystemClock.Now()) {
+        if opts.Countdown != nil {
+            opts.Countdown(left)
+        }
+        if err := SystemClock.Sleep(ctx, min(left, opts.Tick)); err != nil {
+            return nil, err
+        }
+    }
+
+    if opts.Jitter > 0 {
+        if err := SystemClock.Sleep(ctx, time.Duration(rand.Int63n(int64(opts.Jitter)))); err != nil {
+            return nil, err
+        }
+    }
+
+    for attempt := 1; ; attempt++ {
+        input, err := c.FetchInput(ctx, year, day, session, false)
+        if !errors.Is(err, ErrNotUnlocked) || attempt >= opts.Attempts {
+            return input, err
+        }
+        if err := SystemClock.Sleep(ctx, opts.Tick); err != nil {
+            return nil, err
+        }
+    }
+}
@@ -0,0 +1,127 @@
+package solver
+
+import (
+    "context"
+    "errors"
+    "net/http"
+    "testing"
+    "time"
+)
+
+func TestNextUnlock(t *testing.T) {
+    est := time.FixedZone("EST", -5*60*60)
+
+    tests := []TestCase[time.Time, [2]string]{
+        {time.Date(2026, time.October, 18, 12, 0, 0, 0, est), [2]string{"2026", "1"}},
+        {time.Date(2026, time.November, 30, 23, 59, 0, 0, est), [2]string{"2026", "1"}},
+        {time.Date(2026, time.December, 1, 0, 0, 0, 0, est), [2]string{"2026", "2"}},
+        {time.Date(2026, time.December, 5, 4, 59, 0, 0, time.UTC), [2]string{"2026", "5"}},
+        {time.Date(2026, time.December, 24, 23, 0, 0, 0, est), [2]string{"2026", "25"}},
+        {time.Date(2026, time.December, 25, 0, 0, 0, 0, est), [2]string{"2027", "1"}},
+    }
+
+    for _, test := range tests {
+        year, day := NextUnlock(test.input)
+        if year != test.expected[0] || day != test.expected[1] {
+            t.Fatalf("NextUnlock(%v) = %v, %v, want %v, %v", test.input, year, day, test.expected[0], test.expected[1])
+        }
+    }
+}
+
+func TestWaitForInput(t *testing.T) {
+    unlock := time.Date(2023, time.December, 5, 5, 0, 0, 0, time.UTC)
+    clock := withClock(t, unlock.Add(-3*time.Second - 500*time.Millisecond))
+    fake := &sequenceClient{responses: []fakeResponse{
+        {status: http.StatusNotFound, body: "Please don't repeatedly request this endpoint before it unlocks!"},
+        {status: http.StatusOK, body: "1 2 3\n"},
+    }}
+    withClient(t, fake)
+
+    countdown := []time.Duration{}
+    opts := WaitOptions{Tick: time.Second, Attempts: 3, Countdown: func(left time.Duration) {
+        countdown = append(countdown, left)
+        if len(fake.requests) > 0 {
+            t.Fatalf("WaitForInput(5) asked for the input %v before the unlock", left)
+        }
+    }}
+
+    cache := Cache{Dir: t.TempDir()}
+    input, err := cache.WaitForInput(context.Background(), "2023", "5", "secret", opts)
+    if err != nil || string(input) != "1 2 3\n" || len(fake.requests) != 2 {
+        t.Fatalf("WaitForInput(5) = %q, %v after %v requests, want %q after %v", input, err, len(fake.requests), "1 2 3\n", 2)
+    }
+
+    expected := []time.Duration{3500 * time.Millisecond, 2500 * time.Millisecond, 1500 * time.Millisecond, 500 * time.Millisecond}
+    if len(countdown) != len(expected) {
+        t.Fatalf("WaitForInput(5) counted down %v, want %v", countdown, expected)
+    }
+    for idx := range expected {
+        if countdown[idx] != expected[idx] {
+            t.Fatalf("WaitForInput(5) counted down %v, want %v", countdown, expected)
+        }
+    }
+
+    if !clock.now.Equal(unlock.Add(time.Second)) {
+        t.Fatalf("WaitForInput(5) finished at %v, want %v", clock.now, unlock.Add(time.Second))
+    }
+}
+
+func TestWaitForInputJitter(t *testing.T) {
+    unlock := time.Date(2023, time.December, 5, 5, 0, 0, 0, time.UTC)
+    clock := withClock(t, unlock)
+    withClient(t, &sequenceClient{responses: []fakeResponse{{status: http.StatusOK, body: "1 2 3\n"}}})
+
+    opts := WaitOptions{Tick: time.Second, Jitter: 2 * time.Second, Attempts: 1}
+
+    cache := Cache{Dir: t.TempDir()}
+    if _, err := cache.WaitForInput(context.Background(), "2023", "5", "secret", opts); err != nil {
+        t.Fatalf("WaitForInput(5) = %v, want %v", err, nil)
+    }
+
+    if waited := clock.now.Sub(unlock); waited < 0 || waited >= opts.Jitter {
+        t.Fatalf("WaitForInput(5) waited %v after the unlock, want less than %v", waited, opts.Jitter)
+    }
+}
+
+func TestWaitForInputGivesUp(t *testing.T) {
+    withClock(t, time.Date(2023, time.December, 5, 5, 0, 0, 0, time.UTC))
+    fake := &sequenceClient{responses: []fakeResponse{{status: http.StatusNotFound, body: "Please don't repeatedly request this endpoint before it unlocks!"}}}
+    withClient(t, fake)
+
+    opts := WaitOptions{Tick: time.Second, Attempts: 3}
+
+    cache := Cache{Dir: t.TempDir()}
+    _, err := cache.WaitForInput(context.Background(), "2023", "5", "secret", opts)
+    if !errors.Is(err, ErrNotUnlocked) || len(fake.requests) != opts.Attempts {
+        t.Fatalf("WaitForInput(5) = %v after %v requests, want %v after %v", err, len(fake.requests), ErrNotUnlocked, opts.Attempts)
+    }
+}
+
+func TestWaitForInputDefaultTick(t *testing.T) {
+    unlock := time.Date(2023, time.December, 5, 5, 0, 0, 0, time.UTC)
+    clock := withClock(t, unlock.Add(-3*time.Second))
+    withClient(t, &sequenceClient{responses: []fakeResponse{{status: http.StatusOK, body: "1 2 3\n"}}})
+
+    cache := Cache{Dir: t.TempDir()}
+    if _, err := cache.WaitForInput(context.Background(), "2023", "5", "secret", WaitOptions{}); err != nil {
+        t.Fatalf("WaitForInput(5) = %v, want %v", err, nil)
+    }
+    if len(clock.slept) != 3 {
+        t.Fatalf("WaitForInput(5) without a tick waited %v, want %v ticks", clock.slept, 3)
+    }
+}
+
+func TestWaitForInputCancelled(t *testing.T) {
+    withClock(t, time.Date(2023, time.December, 4, 5, 0, 0, 0, time.UTC))
+    fake := &sequenceClient{responses: []fakeResponse{{status: http.StatusOK, body: "1 2 3\n"}}}
+    withClient(t, fake)
+
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+
+    cache := Cache{Dir: t.TempDir()}
+    _, err := cache.WaitForInput(ctx, "2023", "5", "secret", DefaultWaitOptions)
+    if !errors.Is(err, context.Canceled) || len(fake.requests) != 0 {
+        t.Fatalf("WaitForInput(5) = %v after %v requests, want %v without requests", err, len(fake.requests), context.Canceled)
+    }
+}
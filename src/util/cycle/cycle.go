@@ -0,0 +1,131 @@
+package cycle
+
+type (
+	// `StepFunction` computes the state that follows `state`.
+	StepFunction[S any] func(state S) S
+
+	// `KeyFunction` reduces a state to a comparable key. Two states with the
+	// same key are considered equal.
+	KeyFunction[S any, K comparable] func(state S) K
+
+	// `Cycle` describes a sequence of states that, after `Prefix` steps,
+	// repeats itself every `Period` steps.
+	Cycle[S any] struct {
+		Prefix  int
+		Period  int
+		initial S
+		step    StepFunction[S]
+		states  []S
+	}
+)
+
+// `Brent` finds the cycle in the sequence starting at `initial` using Brent's
+// algorithm, which only keeps a few states in memory. The sequence must
+// eventually repeat, or this never returns.
+func Brent[S any, K comparable](initial S, step StepFunction[S], key KeyFunction[S, K]) Cycle[S] {
+	power := 1
+	period := 1
+	tortoise := initial
+	hare := step(initial)
+	for key(tortoise) != key(hare) {
+		if power == period {
+			tortoise = hare
+			power *= 2
+			period = 0
+		}
+		hare = step(hare)
+		period += 1
+	}
+
+	tortoise = initial
+	hare = initial
+	for i := 0; i < period; i++ {
+		hare = step(hare)
+	}
+
+	prefix := 0
+	for key(tortoise) != key(hare) {
+		tortoise = step(tortoise)
+		hare = step(hare)
+		prefix += 1
+	}
+
+	return Cycle[S]{prefix, period, initial, step, nil}
+}
+
+// `Floyd` finds the cycle in the sequence starting at `initial` using Floyd's
+// tortoise and hare algorithm, which only keeps a few states in memory. The
+// sequence must eventually repeat, or this never returns.
+func Floyd[S any, K comparable](initial S, step StepFunction[S], key KeyFunction[S, K]) Cycle[S] {
+	tortoise := step(initial)
+	hare := step(step(initial))
+	for key(tortoise) != key(hare) {
+		tortoise = step(tortoise)
+		hare = step(step(hare))
+	}
+
+	prefix := 0
+	tortoise = initial
+	for key(tortoise) != key(hare) {
+		tortoise = step(tortoise)
+		hare = step(hare)
+		prefix += 1
+	}
+
+	period := 1
+	hare = step(tortoise)
+	for key(tortoise) != key(hare) {
+		hare = step(hare)
+		period += 1
+	}
+
+	return Cycle[S]{prefix, period, initial, step, nil}
+}
+
+// `Detect` finds the cycle in the sequence starting at `initial` by
+// remembering the key of every state it sees. It calls `step` the fewest
+// times of all algorithms and keeps all states, so looking them up later is
+// free. The sequence must eventually repeat, or this never returns.
+func Detect[S any, K comparable](initial S, step StepFunction[S], key KeyFunction[S, K]) Cycle[S] {
+	seen := map[K]int{}
+	states := []S{}
+
+	state := initial
+	for {
+		k := key(state)
+		if first, ok := seen[k]; ok {
+			return Cycle[S]{first, len(states) - first, initial, step, states}
+		}
+		seen[k] = len(states)
+		states = append(states, state)
+		state = step(state)
+	}
+}
+
+// `Index` maps step `n` to the earliest step with the same state, which is
+// always smaller than `Prefix + Period`.
+func (c Cycle[S]) Index(n int) int {
+	if n < c.Prefix+c.Period {
+		return n
+	}
+	return c.Prefix + (n-c.Prefix)%c.Period
+}
+
+// `StateAt` returns the state after `n` steps.
+func (c Cycle[S]) StateAt(n int) S {
+	idx := c.Index(n)
+	if idx < len(c.states) {
+		return c.states[idx]
+	}
+
+	state := c.initial
+	for i := 0; i < idx; i++ {
+		state = c.step(state)
+	}
+	return state
+}
+
+// `ValueAt` derives a value from the state after `n` steps.
+func ValueAt[S any, V any](c Cycle[S], n int, derive func(state S) V) V {
+	return derive(c.StateAt(n))
+}
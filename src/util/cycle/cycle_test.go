@@ -0,0 +1,66 @@
+package cycle
+
+import (
+	"testing"
+)
+
+type (
+	TestCase[I any, E any] struct {
+		input    I
+		expected E
+	}
+)
+
+// a sequence 0, 1, 2, 3, 4, 5, 6, 3, 4, 5, 6, 3, ... with prefix 3 and period 4
+func step(state int) int {
+	if state == 6 {
+		return 3
+	}
+	return state + 1
+}
+
+func identity(state int) int {
+	return state
+}
+
+func TestAlgorithms(t *testing.T) {
+	algorithms := map[string]Cycle[int]{
+		"Brent":  Brent(0, step, identity),
+		"Floyd":  Floyd(0, step, identity),
+		"Detect": Detect(0, step, identity),
+	}
+
+	for name, c := range algorithms {
+		if c.Prefix != 3 || c.Period != 4 {
+			t.Fatalf("%v found prefix %v and period %v, want %v and %v", name, c.Prefix, c.Period, 3, 4)
+		}
+	}
+}
+
+func TestStateAt(t *testing.T) {
+	testcases := []TestCase[int, int]{
+		{0, 0},
+		{2, 2},
+		{6, 6},
+		{7, 3},
+		{10, 6},
+		{1_000_000_000, 3 + (1_000_000_000-3)%4},
+	}
+
+	for _, c := range []Cycle[int]{Brent(0, step, identity), Detect(0, step, identity)} {
+		for _, tc := range testcases {
+			if actual := c.StateAt(tc.input); actual != tc.expected {
+				t.Fatalf("StateAt(%v) = %v, want %v", tc.input, actual, tc.expected)
+			}
+		}
+	}
+}
+
+func TestValueAt(t *testing.T) {
+	c := Floyd(0, step, identity)
+
+	actual := ValueAt(c, 11, func(state int) int { return state * state })
+	if actual != 9 {
+		t.Fatalf("ValueAt(11, square) = %v, want %v", actual, 9)
+	}
+}